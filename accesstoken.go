@@ -9,7 +9,12 @@ import (
 // NewClientWithAccessToken creates a new instance of the Asana client which uses a
 // Personal Access Token for authentication
 func NewClientWithAccessToken(accessToken string) *Client {
-	ctx := context.Background()
+	return NewClientWithAccessTokenContext(context.Background(), accessToken)
+}
+
+// NewClientWithAccessTokenContext is like NewClientWithAccessToken, but the
+// underlying HTTP client is derived from ctx as described in the oauth2 package
+func NewClientWithAccessTokenContext(ctx context.Context, accessToken string) *Client {
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{
 		AccessToken: accessToken,
 	})
@@ -3,6 +3,7 @@ package asana // import "bitbucket.org/mikehouston/asana-go"
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/rs/xid"
 
@@ -45,8 +47,25 @@ type Client struct {
 	BaseURL    *url.URL
 	HTTPClient *http.Client
 
+	// DownloadClient fetches attachment content which is not served by the
+	// API host, so should not add the API credentials. Defaults to
+	// http.DefaultClient.
+	DownloadClient *http.Client
+
 	Verbose        []bool
 	DefaultOptions Options
+
+	// Retry controls how failed requests are retried. Requests are not
+	// retried if this is nil.
+	Retry *RetryPolicy
+
+	// RateLimiter throttles requests to stay within the API quotas. Requests
+	// are not throttled if this is nil.
+	RateLimiter *RateLimiter
+
+	// Objects found by Resolve, keyed by resource type, workspace and
+	// reference
+	resolved sync.Map
 }
 
 // NewClient instantiates a new Asana client with the given HTTP client and
@@ -76,6 +95,10 @@ type Response struct {
 	Data     json.RawMessage `json:"data"`
 	NextPage *NextPage       `json:"next_page"`
 	Errors   []*Error        `json:"errors"`
+
+	// Returned by the events endpoint
+	Sync    string `json:"sync,omitempty"`
+	HasMore bool   `json:"has_more,omitempty"`
 }
 
 func (c *Client) getURL(path string) string {
@@ -102,7 +125,18 @@ func mergeQuery(q url.Values, request interface{}) error {
 	return nil
 }
 
-func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*NextPage, error) {
+func (c *Client) get(ctx context.Context, path string, data, result interface{}, opts ...*Options) (*NextPage, error) {
+	resultData, err := c.getResponse(ctx, path, data, result, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resultData.NextPage, nil
+}
+
+// getResponse makes a GET request, returning the full response envelope. If
+// the API returns an error the response is also returned where available.
+func (c *Client) getResponse(ctx context.Context, path string, data, result interface{}, opts ...*Options) (*Response, error) {
 	requestID := xid.New()
 
 	// Prepare options
@@ -155,23 +189,69 @@ func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*
 	if IsTrue(options.Debug) {
 		log.Printf("%s GET %s", requestID, path)
 	}
-	request, err := http.NewRequest(http.MethodGet, c.getURL(path), nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%s Request error", requestID)
-	}
-	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%s GET error", requestID)
-	}
+	return c.send(ctx, http.MethodGet, path, nil, "", result, requestID, options)
+}
 
-	// Parse the result
-	resultData, err := c.parseResponse(resp, result, requestID, options)
-	if err != nil {
-		return nil, err
+// A bodyFunc provides the body for each attempt at a request. It returns
+// errNotReplayable if the body cannot be provided again for a retry.
+type bodyFunc func() (io.Reader, error)
+
+var errNotReplayable = errors.New("request body cannot be replayed")
+
+// send makes an HTTP request to the API and parses the response, retrying
+// recoverable failures according to the client's RetryPolicy
+func (c *Client) send(ctx context.Context, method, path string, body bodyFunc, contentType string, result interface{}, requestID xid.ID, options *Options) (*Response, error) {
+	var r io.Reader
+	if body != nil {
+		var err error
+		if r, err = body(); err != nil {
+			return nil, errors.Wrapf(err, "%s Request body error", requestID)
+		}
 	}
 
-	return resultData.NextPage, nil
+	for attempt := 1; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, c.getURL(path), r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s Request error", requestID)
+		}
+		if contentType != "" {
+			request.Header.Add("Content-Type", contentType)
+		}
+		c.addHeaders(request, options)
+
+		release, err := c.RateLimiter.wait(ctx, method)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %s rate limit wait cancelled", requestID, method)
+		}
+		resp, err := c.HTTPClient.Do(request)
+		if err != nil {
+			release()
+			return nil, errors.Wrapf(err, "%s %s error", requestID, method)
+		}
+
+		value, err := c.parseResponse(resp, result, requestID, options)
+		release()
+		if err == nil || attempt >= c.Retry.attempts() || !c.Retry.retryable(err) {
+			return value, err
+		}
+
+		// Prepare the body for the next attempt before waiting, so that
+		// requests which cannot be replayed fail straight away
+		if body != nil {
+			var bodyErr error
+			if r, bodyErr = body(); bodyErr == errNotReplayable {
+				return value, err
+			} else if bodyErr != nil {
+				return nil, errors.Wrapf(bodyErr, "%s Request body error", requestID)
+			}
+		}
+
+		delay := c.Retry.delay(attempt, err)
+		c.info("%s %s %s failed on attempt %d, retrying in %s: %v", requestID, method, path, attempt, delay, err)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, errors.Wrapf(err, "%s %s retry cancelled", requestID, method)
+		}
+	}
 }
 
 func (c *Client) addHeaders(request *http.Request, options *Options) {
@@ -198,19 +278,19 @@ func joinFeatures(features []Feature) string {
 	return b.String()
 }
 
-func (c *Client) post(path string, data, result interface{}, opts ...*Options) error {
-	return c.do(http.MethodPost, path, data, result, opts...)
+func (c *Client) post(ctx context.Context, path string, data, result interface{}, opts ...*Options) error {
+	return c.do(ctx, http.MethodPost, path, data, result, opts...)
 }
 
-func (c *Client) put(path string, data, result interface{}, opts ...*Options) error {
-	return c.do(http.MethodPut, path, data, result, opts...)
+func (c *Client) put(ctx context.Context, path string, data, result interface{}, opts ...*Options) error {
+	return c.do(ctx, http.MethodPut, path, data, result, opts...)
 }
 
-func (c *Client) delete(path string, opts ...*Options) error {
-	return c.do(http.MethodDelete, path, nil, nil, opts...)
+func (c *Client) delete(ctx context.Context, path string, opts ...*Options) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil, opts...)
 }
 
-func (c *Client) do(method, path string, data, result interface{}, opts ...*Options) error {
+func (c *Client) do(ctx context.Context, method, path string, data, result interface{}, opts ...*Options) error {
 
 	requestID := xid.New()
 
@@ -244,19 +324,10 @@ func (c *Client) do(method, path string, data, result interface{}, opts ...*Opti
 		body, _ := json.MarshalIndent(req, "", "  ")
 		log.Printf("%s %s %s\n%s", requestID, method, path, body)
 	}
-	request, err := http.NewRequest(method, c.getURL(path), bytes.NewReader(body))
-	if err != nil {
-		return errors.Wrap(err, "Request error")
+	newBody := func() (io.Reader, error) {
+		return bytes.NewReader(body), nil
 	}
-
-	request.Header.Add("Content-Type", "application/json")
-	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
-	if err != nil {
-		return errors.Wrapf(err, "%s error", method)
-	}
-
-	_, err = c.parseResponse(resp, result, requestID, options)
+	_, err = c.send(ctx, method, path, newBody, "application/json", result, requestID, options)
 	return err
 }
 
@@ -281,7 +352,7 @@ func escapeQuotes(s string) string {
 
 // --------
 
-func (c *Client) postMultipart(path string, result interface{}, field string, r io.ReadCloser, filename string, contentType string, opts ...*Options) error {
+func (c *Client) postMultipart(ctx context.Context, path string, result interface{}, field string, r io.ReadCloser, filename string, contentType string, opts ...*Options) error {
 	// Make request
 	requestID := xid.New()
 	options, err := c.mergeOptions(opts...)
@@ -314,23 +385,35 @@ func (c *Client) postMultipart(path string, result interface{}, field string, r
 		return errors.Wrapf(err, "%s create multipart footer", requestID)
 	}
 
-	// Create request
-	request, err := http.NewRequest(http.MethodPost, c.getURL(path), io.MultiReader(
-		bytes.NewReader(buffer.Bytes()[:headerSize]),
-		r,
-		bytes.NewReader(buffer.Bytes()[headerSize:])))
-	if err != nil {
-		return errors.Wrapf(err, "%s Request error", requestID)
+	// Retries rewind the file contents, which is only possible if the
+	// reader supports seeking
+	seeker, seekable := r.(io.Seeker)
+	var start int64
+	if seekable {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
 	}
 
-	request.Header.Add("Content-Type", partWriter.FormDataContentType())
-	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
-	if err != nil {
-		return errors.Wrapf(err, "%s POST error", requestID)
+	first := true
+	newBody := func() (io.Reader, error) {
+		if !first {
+			if !seekable {
+				return nil, errNotReplayable
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+		first = false
+
+		return io.MultiReader(
+			bytes.NewReader(buffer.Bytes()[:headerSize]),
+			r,
+			bytes.NewReader(buffer.Bytes()[headerSize:])), nil
 	}
 
-	_, err = c.parseResponse(resp, result, requestID, options)
+	_, err = c.send(ctx, http.MethodPost, path, newBody, partWriter.FormDataContentType(), result, requestID, options)
 	return err
 }
 
@@ -362,9 +445,15 @@ func (c *Client) parseResponse(resp *http.Response, result interface{}, requestI
 	// Check for errors
 	switch resp.StatusCode {
 	case 200: // OK
+		c.RateLimiter.observeSuccess()
 	case 201: // Object created
+		c.RateLimiter.observeSuccess()
 	default:
-		return nil, value.Error(resp, requestID)
+		err := value.Error(resp, requestID)
+		if resp.StatusCode == 429 {
+			c.RateLimiter.observeRateLimit(RetryAfter(err))
+		}
+		return value, err
 	}
 
 	// Decode the data field
@@ -0,0 +1,206 @@
+package asanatest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// call holds the state of a single API request while it is handled
+type call struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	params []string
+	query  url.Values
+	data   object
+	fields []string
+}
+
+func newCall(w http.ResponseWriter, r *http.Request, params []string) (*call, error) {
+	c := &call{
+		w:      w,
+		r:      r,
+		params: params,
+		query:  r.URL.Query(),
+		data:   object{},
+	}
+	if fields := c.query.Get("opt_fields"); fields != "" {
+		c.fields = strings.Split(fields, ",")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body == nil || r.Method == http.MethodGet || mediaType == "multipart/form-data" {
+		return c, nil
+	}
+
+	var body struct {
+		Data    json.RawMessage `json:"data"`
+		Options struct {
+			Fields []string `json:"fields"`
+		} `json:"options"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if r.Method == http.MethodDelete {
+			return c, nil
+		}
+		return nil, fmt.Errorf("Could not parse request data, invalid JSON")
+	}
+	if len(body.Data) > 0 && string(body.Data) != "null" {
+		if err := json.Unmarshal(body.Data, (*map[string]interface{})(&c.data)); err != nil {
+			return nil, fmt.Errorf("data: Value is not an object")
+		}
+	}
+	if len(body.Options.Fields) > 0 {
+		c.fields = body.Options.Fields
+	}
+	return c, nil
+}
+
+func (c *call) param(i int) string {
+	return c.params[i]
+}
+
+// has reports whether a field was provided in the request data, even if it
+// was null
+func (c *call) has(key string) bool {
+	_, ok := c.data[key]
+	return ok
+}
+
+func (c *call) str(key string) string {
+	return c.data.str(key)
+}
+
+// strings returns a list of strings from the request data
+func (c *call) strings(key string) []string {
+	var result []string
+	switch v := c.data[key].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+	case string:
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+func (c *call) respond(status int, data interface{}) {
+	writeJSON(c.w, status, map[string]interface{}{"data": data})
+}
+
+// ok returns the rendered object
+func (c *call) ok(s *Server, obj object) {
+	c.respond(http.StatusOK, s.render(obj, c.fields))
+}
+
+// created returns the rendered object with a 201 status
+func (c *call) created(s *Server, obj object) {
+	c.respond(http.StatusCreated, s.render(obj, c.fields))
+}
+
+// empty returns an empty data object
+func (c *call) empty() {
+	c.respond(http.StatusOK, map[string]interface{}{})
+}
+
+func (c *call) fail(status int, format string, args ...interface{}) {
+	writeError(c.w, status, fmt.Sprintf(format, args...))
+}
+
+func (c *call) badRequest(format string, args ...interface{}) {
+	c.fail(http.StatusBadRequest, format, args...)
+}
+
+func (c *call) notFound(resourceType, id string) {
+	c.fail(http.StatusNotFound, "%s: Unknown object: %s", resourceType, id)
+}
+
+func (c *call) forbidden(format string, args ...interface{}) {
+	c.fail(http.StatusForbidden, format, args...)
+}
+
+// list returns a page of objects. Objects are returned in compact form
+// unless fields are requested.
+func (c *call) list(s *Server, objects []object) {
+	start, end, next, err := c.page(len(objects))
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+
+	data := []interface{}{}
+	for _, obj := range objects[start:end] {
+		if len(c.fields) == 0 {
+			data = append(data, s.compact(obj.str("gid")))
+		} else {
+			data = append(data, s.render(obj, c.fields))
+		}
+	}
+
+	var nextPage interface{}
+	if next != "" {
+		q := c.r.URL.Query()
+		q.Set("offset", next)
+		path := c.r.URL.Path + "?" + q.Encode()
+		nextPage = map[string]interface{}{
+			"offset": next,
+			"path":   path,
+			"uri":    s.URL + path,
+		}
+	}
+
+	writeJSON(c.w, http.StatusOK, map[string]interface{}{
+		"data":      data,
+		"next_page": nextPage,
+	})
+}
+
+// page returns the range of items to return for the limit and offset
+// parameters, and the offset of the following page if there is one
+func (c *call) page(total int) (start, end int, next string, err error) {
+	end = total
+	if offset := c.query.Get("offset"); offset != "" {
+		if start, err = decodeOffset(offset); err != nil || start > total {
+			return 0, 0, "", fmt.Errorf("offset: Your pagination token is invalid.")
+		}
+	}
+
+	limit := c.query.Get("limit")
+	if limit == "" {
+		return start, end, "", nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 || n > 100 {
+		return 0, 0, "", fmt.Errorf("limit: Must be between 1 and 100")
+	}
+
+	if start+n < total {
+		end = start + n
+		next = encodeOffset(end)
+	}
+	return start, end, next, nil
+}
+
+func encodeOffset(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeOffset(token string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(b), "offset:") {
+		return 0, fmt.Errorf("invalid offset")
+	}
+	return strconv.Atoi(strings.TrimPrefix(string(b), "offset:"))
+}
@@ -0,0 +1,266 @@
+package asanatest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func (s *Server) listStories(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.find("story", func(story object) bool {
+			return story.ref("target") == task.str("gid")
+		}))
+	}
+}
+
+func (s *Server) createStory(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if c.str("text") == "" && c.str("html_text") == "" {
+		c.badRequest("text: Missing input")
+		return
+	}
+
+	story := s.create("story", object{
+		"type":             "comment",
+		"resource_subtype": "comment_added",
+		"text":             c.str("text"),
+		"html_text":        c.str("html_text"),
+		"is_pinned":        c.data["is_pinned"] == true,
+		"is_edited":        false,
+		"created_by":       ref(s.me),
+		"target":           ref(task.str("gid")),
+		"source":           "api",
+	})
+	c.created(s, story)
+}
+
+func (s *Server) story(c *call) object {
+	story := s.lookup(c.param(0), "story")
+	if story == nil {
+		c.notFound("story", c.param(0))
+	}
+	return story
+}
+
+func (s *Server) getStory(c *call) {
+	if story := s.story(c); story != nil {
+		c.ok(s, story)
+	}
+}
+
+func (s *Server) updateStory(c *call) {
+	story := s.story(c)
+	if story == nil {
+		return
+	}
+	if story.str("type") != "comment" {
+		c.forbidden("Only comments can be edited")
+		return
+	}
+	for _, key := range []string{"text", "html_text"} {
+		if c.has(key) {
+			story[key] = c.str(key)
+			story["is_edited"] = true
+		}
+	}
+	if c.has("is_pinned") {
+		story["is_pinned"] = c.data["is_pinned"] == true
+	}
+	c.ok(s, story)
+}
+
+func (s *Server) deleteStory(c *call) {
+	story := s.story(c)
+	if story == nil {
+		return
+	}
+	if story.str("type") != "comment" {
+		c.forbidden("Only comments can be deleted")
+		return
+	}
+	s.remove(story.str("gid"))
+	c.empty()
+}
+
+func (s *Server) listAttachments(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.find("attachment", func(attachment object) bool {
+			return attachment.ref("parent") == task.str("gid")
+		}))
+	}
+}
+
+// createAttachment accepts either a multipart file upload or a JSON request
+// for an external attachment
+func (s *Server) createAttachment(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+
+	props := object{
+		"parent":     ref(task.str("gid")),
+		"created_by": ref(s.me),
+	}
+	var content []byte
+	if c.has("url") {
+		if c.str("resource_subtype") != "external" {
+			c.badRequest("resource_subtype: Must be external for URL attachments")
+			return
+		}
+		props["name"] = c.str("name")
+		props["resource_subtype"] = "external"
+		props["host"] = "external"
+		props["view_url"] = c.str("url")
+		props["permanent_url"] = c.str("url")
+		props["download_url"] = nil
+	} else {
+		file, header, err := c.r.FormFile("file")
+		if err != nil {
+			c.badRequest("file: Missing input")
+			return
+		}
+		defer file.Close()
+		if content, err = io.ReadAll(file); err != nil {
+			c.fail(http.StatusInternalServerError, "%v", err)
+			return
+		}
+		props["name"] = header.Filename
+		props["resource_subtype"] = "asana"
+		props["host"] = "asana"
+		props["size"] = len(content)
+	}
+
+	attachment := s.create("attachment", props)
+	if content != nil {
+		id := attachment.str("gid")
+		s.files[id] = content
+		attachment["view_url"] = fmt.Sprintf("%s/_download/%s", s.URL, id)
+		attachment["permanent_url"] = fmt.Sprintf("%s/_download/%s", s.URL, id)
+	}
+	c.ok(s, attachment)
+}
+
+// downloadURL returns the current download URL for an uploaded file
+func (s *Server) downloadURL(id string) string {
+	return fmt.Sprintf("%s/_download/%s?key=%d", s.URL, id, s.downloadKey)
+}
+
+func (s *Server) getAttachment(c *call) {
+	attachment := s.lookup(c.param(0), "attachment")
+	if attachment == nil {
+		c.notFound("attachment", c.param(0))
+		return
+	}
+	c.ok(s, attachment)
+}
+
+func (s *Server) deleteAttachment(c *call) {
+	if s.lookup(c.param(0), "attachment") == nil {
+		c.notFound("attachment", c.param(0))
+		return
+	}
+	delete(s.files, c.param(0))
+	s.remove(c.param(0))
+	c.empty()
+}
+
+// The tag properties which can be set directly
+var tagFields = []string{"name", "notes", "color"}
+
+func (s *Server) newTag(c *call, workspace string) (object, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace: Missing input")
+	}
+	if s.lookup(workspace, "workspace") == nil {
+		return nil, fmt.Errorf("workspace: Unknown object: %s", workspace)
+	}
+	if c.str("name") == "" {
+		return nil, fmt.Errorf("name: Missing input")
+	}
+
+	tag := object{
+		"workspace": ref(workspace),
+		"notes":     "",
+		"color":     nil,
+		"followers": []interface{}{},
+	}
+	for _, key := range tagFields {
+		if value, ok := c.data[key]; ok {
+			tag[key] = value
+		}
+	}
+	return s.create("tag", tag), nil
+}
+
+func (s *Server) listTags(c *call) {
+	if s.lookup(c.param(0), "workspace") == nil {
+		c.notFound("workspace", c.param(0))
+		return
+	}
+	c.list(s, s.find("tag", func(tag object) bool {
+		return tag.ref("workspace") == c.param(0)
+	}))
+}
+
+func (s *Server) createWorkspaceTag(c *call) {
+	tag, err := s.newTag(c, c.param(0))
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, tag)
+}
+
+func (s *Server) createTag(c *call) {
+	tag, err := s.newTag(c, c.str("workspace"))
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, tag)
+}
+
+func (s *Server) tag(c *call) object {
+	tag := s.lookup(c.param(0), "tag")
+	if tag == nil {
+		c.notFound("tag", c.param(0))
+	}
+	return tag
+}
+
+func (s *Server) getTag(c *call) {
+	if tag := s.tag(c); tag != nil {
+		c.ok(s, tag)
+	}
+}
+
+func (s *Server) updateTag(c *call) {
+	tag := s.tag(c)
+	if tag == nil {
+		return
+	}
+	for _, key := range tagFields {
+		if value, ok := c.data[key]; ok {
+			tag[key] = value
+		}
+	}
+	c.ok(s, tag)
+}
+
+func (s *Server) deleteTag(c *call) {
+	tag := s.tag(c)
+	if tag == nil {
+		return
+	}
+	id := tag.str("gid")
+	for _, task := range s.find("task", nil) {
+		task.removeRef("tags", id)
+	}
+	s.remove(id)
+	c.empty()
+}
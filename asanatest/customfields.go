@@ -0,0 +1,311 @@
+package asanatest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The custom field properties which can be set directly
+var customFieldFields = []string{
+	"name", "description", "precision", "format", "currency_code",
+	"custom_label", "custom_label_position", "has_notifications_enabled",
+}
+
+var customFieldTypes = map[string]bool{
+	"text": true, "number": true, "enum": true, "multi_enum": true,
+	"date": true, "people": true, "boolean": true,
+}
+
+// newCustomField creates a custom field from its definition
+func (s *Server) newCustomField(definition object) (object, error) {
+	workspace := definition.str("workspace")
+	if s.lookup(workspace, "workspace") == nil {
+		return nil, fmt.Errorf("workspace: Unknown object: %s", workspace)
+	}
+	if definition.str("name") == "" {
+		return nil, fmt.Errorf("name: Missing input")
+	}
+	subtype := definition.str("resource_subtype")
+	if subtype == "" {
+		subtype = definition.str("type")
+	}
+	if !customFieldTypes[subtype] {
+		return nil, fmt.Errorf("resource_subtype: Invalid custom field type: %s", subtype)
+	}
+
+	field := object{
+		"workspace":              ref(workspace),
+		"resource_subtype":       subtype,
+		"type":                   subtype,
+		"description":            "",
+		"is_global_to_workspace": true,
+		"enabled":                true,
+	}
+	for _, key := range customFieldFields {
+		if value, ok := definition[key]; ok {
+			field[key] = value
+		}
+	}
+	if subtype == "number" {
+		if _, ok := field["precision"].(float64); !ok {
+			field["precision"] = float64(0)
+		}
+	}
+
+	if subtype == "enum" || subtype == "multi_enum" {
+		options := []interface{}{}
+		list, _ := definition["enum_options"].([]interface{})
+		for _, item := range list {
+			definition, _ := item.(map[string]interface{})
+			option := object{
+				"name":    object(definition).str("name"),
+				"color":   "none",
+				"enabled": true,
+			}
+			if color := object(definition).str("color"); color != "" {
+				option["color"] = color
+			}
+			if enabled, ok := definition["enabled"].(bool); ok {
+				option["enabled"] = enabled
+			}
+			options = append(options, embed(s.create("enum_option", option).str("gid")))
+		}
+		field["enum_options"] = options
+	}
+
+	return s.create("custom_field", field), nil
+}
+
+func (s *Server) listCustomFields(c *call) {
+	if s.lookup(c.param(0), "workspace") == nil {
+		c.notFound("workspace", c.param(0))
+		return
+	}
+	c.list(s, s.find("custom_field", func(field object) bool {
+		return field.ref("workspace") == c.param(0) && field["is_global_to_workspace"] == true
+	}))
+}
+
+func (s *Server) createCustomField(c *call) {
+	field, err := s.newCustomField(c.data)
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, field)
+}
+
+func (s *Server) customField(c *call) object {
+	field := s.lookup(c.param(0), "custom_field")
+	if field == nil {
+		c.notFound("custom_field", c.param(0))
+	}
+	return field
+}
+
+func (s *Server) getCustomField(c *call) {
+	if field := s.customField(c); field != nil {
+		c.ok(s, field)
+	}
+}
+
+func (s *Server) updateCustomField(c *call) {
+	field := s.customField(c)
+	if field == nil {
+		return
+	}
+	for _, key := range customFieldFields {
+		if value, ok := c.data[key]; ok {
+			field[key] = value
+		}
+	}
+	c.ok(s, field)
+}
+
+func (s *Server) deleteCustomField(c *call) {
+	field := s.customField(c)
+	if field == nil {
+		return
+	}
+	id := field.str("gid")
+	for _, setting := range s.find("custom_field_setting", func(setting object) bool {
+		return setting["custom_field"] == embed(id)
+	}) {
+		if project := s.objects[setting.ref("parent")]; project != nil {
+			project["_settings"] = removeID(project.ids("_settings"), setting.str("gid"))
+		}
+		s.remove(setting.str("gid"))
+	}
+	for _, task := range s.find("task", nil) {
+		delete(task["_custom_fields"].(map[string]interface{}), id)
+	}
+	for _, option := range field.refs("enum_options") {
+		s.remove(option)
+	}
+	s.remove(id)
+	c.empty()
+}
+
+// customFieldValue validates a custom field value from a task request and
+// returns the value to store, or nil to clear the value
+func (s *Server) customFieldValue(id string, value interface{}) (interface{}, error) {
+	field := s.lookup(id, "custom_field")
+	if field == nil {
+		return nil, fmt.Errorf("custom_fields: Unknown object: %s", id)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	invalid := fmt.Errorf("custom_fields: Invalid value for %s field %s: %v", field.str("resource_subtype"), id, value)
+	switch field.str("resource_subtype") {
+	case "text":
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+
+	case "number":
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				return n, nil
+			}
+		}
+
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+
+	case "enum":
+		if option, ok := value.(string); ok && containsID(field.refs("enum_options"), option) {
+			return option, nil
+		}
+
+	case "multi_enum", "people":
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, invalid
+		}
+		ids := []interface{}{}
+		for _, item := range list {
+			id, _ := item.(string)
+			if field.str("resource_subtype") == "people" {
+				user := s.user(id)
+				if user == nil {
+					return nil, invalid
+				}
+				id = user.str("gid")
+			} else if !containsID(field.refs("enum_options"), id) {
+				return nil, invalid
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+
+	case "date":
+		if date, ok := value.(map[string]interface{}); ok {
+			if date["date"] != nil || date["date_time"] != nil {
+				return date, nil
+			}
+		}
+	}
+	return nil, invalid
+}
+
+// taskCustomFields renders the custom field values of a task, including the
+// fields of all its projects
+func (s *Server) taskCustomFields(task object) []interface{} {
+	values, _ := task["_custom_fields"].(map[string]interface{})
+
+	var ids []string
+	for _, membership := range task.memberships() {
+		project := s.objects[string(membership["project"].(ref))]
+		if project == nil {
+			continue
+		}
+		for _, setting := range s.objectsByID(project.ids("_settings")) {
+			if id, ok := setting["custom_field"].(embed); ok && !containsID(ids, string(id)) {
+				ids = append(ids, string(id))
+			}
+		}
+	}
+	for _, id := range s.order {
+		if _, ok := values[id]; ok && !containsID(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	result := []interface{}{}
+	for _, field := range s.objectsByID(ids) {
+		result = append(result, s.renderValue(field, values[field.str("gid")]))
+	}
+	return result
+}
+
+// renderValue renders a custom field with its value on a task
+func (s *Server) renderValue(field object, value interface{}) map[string]interface{} {
+	result := s.render(field, nil)
+	var display interface{}
+
+	switch field.str("resource_subtype") {
+	case "text":
+		result["text_value"] = value
+		display = value
+	case "number":
+		result["number_value"] = value
+		if n, ok := value.(float64); ok {
+			precision, _ := field["precision"].(float64)
+			display = strconv.FormatFloat(n, 'f', int(precision), 64)
+		}
+	case "boolean":
+		result["boolean_value"] = value
+		if b, ok := value.(bool); ok {
+			display = strconv.FormatBool(b)
+		}
+	case "enum":
+		result["enum_value"] = nil
+		if id, ok := value.(string); ok {
+			option := s.resolve(embed(id))
+			result["enum_value"] = option
+			display = option.(map[string]interface{})["name"]
+		}
+	case "multi_enum", "people":
+		list, _ := value.([]interface{})
+		rendered := []interface{}{}
+		var names []string
+		for _, item := range list {
+			id, _ := item.(string)
+			var obj map[string]interface{}
+			if field.str("resource_subtype") == "people" {
+				obj = s.compact(id)
+			} else {
+				obj = s.resolve(embed(id)).(map[string]interface{})
+			}
+			rendered = append(rendered, obj)
+			names = append(names, fmt.Sprint(obj["name"]))
+		}
+		if field.str("resource_subtype") == "people" {
+			result["people_value"] = rendered
+		} else {
+			result["multi_enum_values"] = rendered
+		}
+		if len(names) > 0 {
+			display = strings.Join(names, ", ")
+		}
+	case "date":
+		result["date_value"] = value
+		if date, ok := value.(map[string]interface{}); ok {
+			display = date["date"]
+			if date["date_time"] != nil {
+				display = date["date_time"]
+			}
+		}
+	}
+
+	result["display_value"] = display
+	return result
+}
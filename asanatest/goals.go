@@ -0,0 +1,435 @@
+package asanatest
+
+import (
+	"strconv"
+	"strings"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+)
+
+// The goal properties which can be set directly
+var goalFields = []string{
+	"name", "notes", "html_notes", "due_on", "start_on", "is_workspace_level", "status",
+}
+
+// AddTimePeriod creates a time period in a workspace, which goals can be set
+// for. Dates use the YYYY-MM-DD format. The parent of the new period is the
+// shortest existing period in the workspace which contains it.
+func (s *Server) AddTimePeriod(workspaceID, period, startOn, endOn string) *asana.TimePeriod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var parent interface{}
+	var parentStart, parentEnd string
+	for _, other := range s.find("time_period", func(other object) bool {
+		return other.ref("workspace") == workspaceID && other.str("start_on") <= startOn && other.str("end_on") >= endOn
+	}) {
+		if parent == nil || other.str("start_on") >= parentStart && other.str("end_on") <= parentEnd {
+			parent = ref(other.str("gid"))
+			parentStart, parentEnd = other.str("start_on"), other.str("end_on")
+		}
+	}
+
+	timePeriod := s.create("time_period", object{
+		"workspace":    ref(workspaceID),
+		"period":       period,
+		"start_on":     startOn,
+		"end_on":       endOn,
+		"display_name": period + " " + startOn[:4],
+		"parent":       parent,
+	})
+
+	result := &asana.TimePeriod{}
+	s.decode(timePeriod, result)
+	return result
+}
+
+func (s *Server) listTimePeriods(c *call) {
+	workspace := c.query.Get("workspace")
+	if s.lookup(workspace, "workspace") == nil {
+		c.badRequest("workspace: Missing input")
+		return
+	}
+	startOn, endOn := c.query.Get("start_on"), c.query.Get("end_on")
+	c.list(s, s.find("time_period", func(timePeriod object) bool {
+		return timePeriod.ref("workspace") == workspace &&
+			(startOn == "" || timePeriod.str("start_on") >= startOn) &&
+			(endOn == "" || timePeriod.str("end_on") <= endOn)
+	}))
+}
+
+func (s *Server) getTimePeriod(c *call) {
+	timePeriod := s.lookup(c.param(0), "time_period")
+	if timePeriod == nil {
+		c.notFound("time_period", c.param(0))
+		return
+	}
+	c.ok(s, timePeriod)
+}
+
+func (s *Server) goal(c *call) object {
+	goal := s.lookup(c.param(0), "goal")
+	if goal == nil {
+		c.notFound("goal", c.param(0))
+	}
+	return goal
+}
+
+func (s *Server) listGoals(c *call) {
+	q := c.query
+	if q.Get("workspace") == "" && q.Get("team") == "" && q.Get("portfolio") == "" && q.Get("project") == "" {
+		c.badRequest("workspace: One of workspace, team, portfolio or project is required")
+		return
+	}
+
+	// Portfolio and project filters match the goals they support
+	supporting := q.Get("portfolio")
+	if supporting == "" {
+		supporting = q.Get("project")
+	}
+	var periods []string
+	if value := q.Get("time_periods"); value != "" {
+		periods = strings.Split(value, ",")
+	}
+
+	c.list(s, s.find("goal", func(goal object) bool {
+		if q.Get("workspace") != "" && goal.ref("workspace") != q.Get("workspace") {
+			return false
+		}
+		if q.Get("team") != "" && goal.ref("team") != q.Get("team") {
+			return false
+		}
+		if supporting != "" && s.supportingRelationship(goal, supporting) == nil {
+			return false
+		}
+		if periods != nil && !containsID(periods, goal.ref("time_period")) {
+			return false
+		}
+		if value := q.Get("is_workspace_level"); value != "" && strconv.FormatBool(goal["is_workspace_level"] == true) != value {
+			return false
+		}
+		return true
+	}))
+}
+
+func (s *Server) createGoal(c *call) {
+	workspace := c.str("workspace")
+	if s.lookup(workspace, "workspace") == nil {
+		c.badRequest("workspace: Unknown object: %s", workspace)
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+
+	goal := object{
+		"workspace":             ref(workspace),
+		"owner":                 ref(s.me),
+		"followers":             []interface{}{ref(s.me)},
+		"team":                  nil,
+		"time_period":           nil,
+		"notes":                 "",
+		"due_on":                nil,
+		"start_on":              nil,
+		"is_workspace_level":    true,
+		"status":                nil,
+		"metric":                nil,
+		"current_status_update": nil,
+		"num_likes":             0,
+	}
+	if !s.setGoalRefs(c, goal) {
+		return
+	}
+	followers, err := s.userRefs(c.strings("followers"))
+	if err != nil {
+		c.badRequest("followers: %v", err)
+		return
+	}
+	for _, follower := range followers {
+		goal.addRef("followers", string(follower.(ref)))
+	}
+	for _, key := range goalFields {
+		if value, ok := c.data[key]; ok {
+			goal[key] = value
+		}
+	}
+	c.created(s, s.create("goal", goal))
+}
+
+// setGoalRefs applies the team, time period and owner of a goal from the
+// request, returning false if the request failed
+func (s *Server) setGoalRefs(c *call, goal object) bool {
+	if c.str("team") != "" {
+		if s.lookup(c.str("team"), "team") == nil {
+			c.badRequest("team: Unknown object: %s", c.str("team"))
+			return false
+		}
+		goal["team"] = ref(c.str("team"))
+		goal["is_workspace_level"] = false
+	}
+	if c.str("time_period") != "" {
+		if s.lookup(c.str("time_period"), "time_period") == nil {
+			c.badRequest("time_period: Unknown object: %s", c.str("time_period"))
+			return false
+		}
+		goal["time_period"] = ref(c.str("time_period"))
+	}
+	if c.str("owner") != "" {
+		owner := s.user(c.str("owner"))
+		if owner == nil {
+			c.badRequest("owner: Unknown object: %s", c.str("owner"))
+			return false
+		}
+		goal["owner"] = ref(owner.str("gid"))
+	}
+	return true
+}
+
+func (s *Server) getGoal(c *call) {
+	if goal := s.goal(c); goal != nil {
+		c.ok(s, goal)
+	}
+}
+
+func (s *Server) updateGoal(c *call) {
+	goal := s.goal(c)
+	if goal == nil || !s.setGoalRefs(c, goal) {
+		return
+	}
+	for _, key := range goalFields {
+		if value, ok := c.data[key]; ok {
+			goal[key] = value
+		}
+	}
+	c.ok(s, goal)
+}
+
+func (s *Server) deleteGoal(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	id := goal.str("gid")
+	for _, relationship := range s.find("goal_relationship", func(relationship object) bool {
+		return relationship.ref("supported_goal") == id || relationship.ref("supporting_resource") == id
+	}) {
+		s.removeRelationship(relationship)
+	}
+	s.remove(id)
+	c.empty()
+}
+
+// The goal metric properties which can be set with setMetric
+var goalMetricFields = []string{
+	"precision", "unit", "currency_code", "initial_number_value", "target_number_value", "progress_source",
+}
+
+func (s *Server) setGoalMetric(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	if subtype, ok := c.data["metric_type"]; ok && subtype != "number" {
+		c.badRequest("metric_type: Must be number")
+		return
+	}
+
+	metric := map[string]interface{}{
+		"resource_subtype":     "number",
+		"precision":            float64(0),
+		"unit":                 "none",
+		"initial_number_value": float64(0),
+		"target_number_value":  float64(0),
+		"progress_source":      "manual",
+	}
+	if previous, ok := goal["metric"].(map[string]interface{}); ok {
+		metric = previous
+	}
+	for _, key := range goalMetricFields {
+		if value, ok := c.data[key]; ok {
+			metric[key] = value
+		}
+	}
+	if _, ok := metric["current_number_value"]; !ok {
+		metric["current_number_value"] = metric["initial_number_value"]
+	}
+	goal["metric"] = formatMetric(metric)
+	c.ok(s, goal)
+}
+
+func (s *Server) setGoalMetricCurrentValue(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	metric, _ := goal["metric"].(map[string]interface{})
+	if metric == nil {
+		c.badRequest("metric: Goal %s does not have a metric", goal.str("gid"))
+		return
+	}
+	if metric["progress_source"] != "manual" {
+		c.badRequest("current_number_value: The metric of goal %s is not updated manually", goal.str("gid"))
+		return
+	}
+	value, ok := c.data["current_number_value"].(float64)
+	if !ok {
+		c.badRequest("current_number_value: Missing input")
+		return
+	}
+	metric["current_number_value"] = value
+	goal["metric"] = formatMetric(metric)
+	c.ok(s, goal)
+}
+
+// formatMetric sets the display value of a goal metric
+func formatMetric(metric map[string]interface{}) map[string]interface{} {
+	value, _ := metric["current_number_value"].(float64)
+	precision, _ := metric["precision"].(float64)
+	display := strconv.FormatFloat(value, 'f', int(precision), 64)
+	switch metric["unit"] {
+	case "percentage":
+		display = strconv.FormatFloat(value*100, 'f', int(precision), 64) + "%"
+	case "currency":
+		code, _ := metric["currency_code"].(string)
+		display = display + " " + code
+	}
+	metric["current_display_value"] = display
+	return metric
+}
+
+func (s *Server) listParentGoals(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	var parents []object
+	for _, relationship := range s.find("goal_relationship", func(relationship object) bool {
+		return relationship.ref("supporting_resource") == goal.str("gid")
+	}) {
+		parents = append(parents, s.objects[relationship.ref("supported_goal")])
+	}
+	c.list(s, parents)
+}
+
+// supportingRelationship returns the relationship between a goal and a
+// resource supporting it, or nil
+func (s *Server) supportingRelationship(goal object, resource string) object {
+	for _, relationship := range s.objectsByID(goal.ids("_relationships")) {
+		if relationship.ref("supporting_resource") == resource {
+			return relationship
+		}
+	}
+	return nil
+}
+
+func (s *Server) removeRelationship(relationship object) {
+	if goal := s.objects[relationship.ref("supported_goal")]; goal != nil {
+		goal["_relationships"] = removeID(goal.ids("_relationships"), relationship.str("gid"))
+	}
+	s.remove(relationship.str("gid"))
+}
+
+func (s *Server) addSupportingRelationship(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	id := c.str("supporting_resource")
+	resource := s.objects[id]
+	subtype := "supporting_work"
+	switch {
+	case resource == nil:
+		c.badRequest("supporting_resource: Unknown object: %s", id)
+		return
+	case id == goal.str("gid"):
+		c.badRequest("supporting_resource: A goal cannot support itself")
+		return
+	case resource["resource_type"] == "goal":
+		subtype = "subgoal"
+	case resource["resource_type"] != "project" && resource["resource_type"] != "portfolio" && resource["resource_type"] != "task":
+		c.badRequest("supporting_resource: A %s cannot support a goal", resource["resource_type"])
+		return
+	}
+	if s.supportingRelationship(goal, id) != nil {
+		c.badRequest("supporting_resource: %s already supports goal %s", id, goal.str("gid"))
+		return
+	}
+
+	weight, ok := c.data["contribution_weight"].(float64)
+	if !ok {
+		weight = 0
+	}
+	relationship := s.create("goal_relationship", object{
+		"resource_subtype":    subtype,
+		"supported_goal":      ref(goal.str("gid")),
+		"supporting_resource": ref(id),
+		"contribution_weight": weight,
+	})
+
+	// Positions are given by the supporting resources of other relationships
+	before, after := c.str("insert_before"), c.str("insert_after")
+	if other := s.supportingRelationship(goal, before); other != nil {
+		before = other.str("gid")
+	}
+	if other := s.supportingRelationship(goal, after); other != nil {
+		after = other.str("gid")
+	}
+	goal["_relationships"] = insertID(goal.ids("_relationships"), relationship.str("gid"), before, after)
+	c.ok(s, relationship)
+}
+
+func (s *Server) removeSupportingRelationship(c *call) {
+	goal := s.goal(c)
+	if goal == nil {
+		return
+	}
+	relationship := s.supportingRelationship(goal, c.str("supporting_resource"))
+	if relationship == nil {
+		c.badRequest("supporting_resource: %s does not support goal %s", c.str("supporting_resource"), goal.str("gid"))
+		return
+	}
+	s.removeRelationship(relationship)
+	c.empty()
+}
+
+func (s *Server) listGoalRelationships(c *call) {
+	goal := s.lookup(c.query.Get("supported_goal"), "goal")
+	if goal == nil {
+		c.badRequest("supported_goal: Missing input")
+		return
+	}
+	subtype := c.query.Get("resource_subtype")
+	var result []object
+	for _, relationship := range s.objectsByID(goal.ids("_relationships")) {
+		if subtype == "" || relationship["resource_subtype"] == subtype {
+			result = append(result, relationship)
+		}
+	}
+	c.list(s, result)
+}
+
+func (s *Server) goalRelationship(c *call) object {
+	relationship := s.lookup(c.param(0), "goal_relationship")
+	if relationship == nil {
+		c.notFound("goal_relationship", c.param(0))
+	}
+	return relationship
+}
+
+func (s *Server) getGoalRelationship(c *call) {
+	if relationship := s.goalRelationship(c); relationship != nil {
+		c.ok(s, relationship)
+	}
+}
+
+func (s *Server) updateGoalRelationship(c *call) {
+	relationship := s.goalRelationship(c)
+	if relationship == nil {
+		return
+	}
+	if weight, ok := c.data["contribution_weight"].(float64); ok {
+		relationship["contribution_weight"] = weight
+	}
+	c.ok(s, relationship)
+}
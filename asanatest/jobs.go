@@ -0,0 +1,408 @@
+package asanatest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FailNextJob makes the next asynchronous job, such as a project
+// duplication, fail instead of creating anything
+func (s *Server) FailNextJob() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failJob = true
+}
+
+// startJob creates a job which runs f unless it has been set to fail. Jobs
+// are reported as in progress until they are first fetched.
+func (s *Server) startJob(subtype string, f func(job object) error) (object, error) {
+	job := object{
+		"resource_subtype":     subtype,
+		"status":               "in_progress",
+		"new_project":          nil,
+		"new_task":             nil,
+		"new_project_template": nil,
+		"_result":              "succeeded",
+	}
+	if s.failJob {
+		s.failJob = false
+		job["_result"] = "failed"
+	} else if err := f(job); err != nil {
+		return nil, err
+	}
+	return s.create("job", job), nil
+}
+
+func (s *Server) getJob(c *call) {
+	job := s.lookup(c.param(0), "job")
+	if job == nil {
+		c.notFound("job", c.param(0))
+		return
+	}
+	job["status"] = job["_result"]
+	c.ok(s, job)
+}
+
+// duplication holds the options for copying tasks
+type duplication struct {
+	// The optional parts of each task to copy, such as notes or subtasks
+	include map[string]bool
+
+	// The number of days to move dates by, and whether dates falling on a
+	// weekend are moved to the following Monday
+	days         int
+	skipWeekends bool
+
+	// The copies of projects, sections and tasks, by the original ID
+	copies map[string]string
+}
+
+// newDuplication parses an include list. Options for a project duplication
+// are given with a task_ prefix for the parts of tasks.
+func newDuplication(include []string) *duplication {
+	d := &duplication{
+		include: make(map[string]bool),
+		copies:  make(map[string]string),
+	}
+	for _, option := range include {
+		d.include[strings.TrimPrefix(option, "task_")] = true
+	}
+	return d
+}
+
+// shift moves a date by the duplication offset, returning nil if dates are
+// not copied
+func (d *duplication) shift(value interface{}) interface{} {
+	date, ok := value.(string)
+	if !ok || !d.include["dates"] {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	t = t.AddDate(0, 0, d.days)
+	if d.skipWeekends {
+		switch t.Weekday() {
+		case time.Saturday:
+			t = t.AddDate(0, 0, 2)
+		case time.Sunday:
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	return t.Format("2006-01-02")
+}
+
+// schedule sets the offset so that the earliest date of the tasks moves to
+// startOn, or the latest due date moves to dueOn
+func (d *duplication) schedule(tasks []object, startOn, dueOn string, skipWeekends bool) error {
+	d.skipWeekends = skipWeekends
+	target, latest := startOn, false
+	if dueOn != "" {
+		target, latest = dueOn, true
+	}
+	if target == "" {
+		return nil
+	}
+	to, err := time.Parse("2006-01-02", target)
+	if err != nil {
+		return fmt.Errorf("schedule_dates: Invalid date: %s", target)
+	}
+
+	var reference string
+	for _, task := range tasks {
+		for _, key := range []string{"start_on", "due_on"} {
+			date := task.str(key)
+			if date == "" || latest && key == "start_on" {
+				continue
+			}
+			if reference == "" || latest && date > reference || !latest && date < reference {
+				reference = date
+			}
+		}
+	}
+	if reference == "" {
+		return nil
+	}
+	from, _ := time.Parse("2006-01-02", reference)
+	d.days = int(to.Sub(from).Hours() / 24)
+	return nil
+}
+
+// taskTree returns the tasks and, if subtasks are included, all their
+// subtasks
+func (s *Server) taskTree(tasks []object, d *duplication) []object {
+	var result []object
+	for _, task := range tasks {
+		result = append(result, task)
+		if d.include["subtasks"] {
+			result = append(result, s.taskTree(s.objectsByID(task.ids("_subtasks")), d)...)
+		}
+	}
+	return result
+}
+
+// copyTask copies a task into the projects and sections recorded in d,
+// along with the parts selected by d.include
+func (s *Server) copyTask(task object, name string, parent object, d *duplication) (object, error) {
+	copied := object{
+		"name":             name,
+		"notes":            "",
+		"resource_subtype": task["resource_subtype"],
+		"completed":        task["completed"],
+		"completed_at":     task["completed_at"],
+		"assignee":         nil,
+		"assignee_status":  "upcoming",
+		"assignee_section": nil,
+		"due_on":           d.shift(task["due_on"]),
+		"due_at":           nil,
+		"start_on":         d.shift(task["start_on"]),
+		"parent":           nil,
+		"workspace":        task["workspace"],
+		"memberships":      []interface{}{},
+		"tags":             []interface{}{},
+		"followers":        []interface{}{ref(s.me)},
+		"dependencies":     []interface{}{},
+		"dependents":       []interface{}{},
+		"modified_at":      now(),
+	}
+	values := map[string]interface{}{}
+	for id, value := range task["_custom_fields"].(map[string]interface{}) {
+		values[id] = value
+	}
+	copied["_custom_fields"] = values
+
+	if d.include["notes"] {
+		copied["notes"] = task["notes"]
+		copied["html_notes"] = task["html_notes"]
+	}
+	if d.include["assignee"] {
+		copied["assignee"] = task["assignee"]
+		copied["assignee_status"] = task["assignee_status"]
+		copied["assignee_section"] = task["assignee_section"]
+	}
+	if d.include["followers"] {
+		copied["followers"] = append([]interface{}(nil), task["followers"].([]interface{})...)
+	}
+	if d.include["tags"] {
+		copied["tags"] = append([]interface{}(nil), task["tags"].([]interface{})...)
+	}
+	if parent == nil && d.include["parent"] {
+		parent = s.objects[task.ref("parent")]
+	}
+
+	copied = s.create("task", copied)
+	id := copied.str("gid")
+	d.copies[task.str("gid")] = id
+	if parent != nil {
+		copied["parent"] = ref(parent.str("gid"))
+		parent["_subtasks"] = append(parent.ids("_subtasks"), id)
+	}
+
+	for _, membership := range task.memberships() {
+		project, _ := membership["project"].(ref)
+		section, _ := membership["section"].(ref)
+		if newProject, ok := d.copies[string(project)]; ok {
+			if err := s.addMembership(copied, newProject, d.copies[string(section)], "", ""); err != nil {
+				return nil, err
+			}
+		} else if d.include["projects"] {
+			if err := s.addMembership(copied, string(project), string(section), "", ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if d.include["attachments"] {
+		for _, attachment := range s.find("attachment", func(attachment object) bool {
+			return attachment.ref("parent") == task.str("gid")
+		}) {
+			s.copyAttachment(attachment, id)
+		}
+	}
+	if d.include["subtasks"] {
+		for _, subtask := range s.objectsByID(task.ids("_subtasks")) {
+			if _, err := s.copyTask(subtask, subtask.str("name"), copied, d); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return copied, nil
+}
+
+// copyDependencies links the copied tasks with the same dependencies as
+// the originals. Dependencies on tasks which were copied point to the copy.
+func (s *Server) copyDependencies(tasks []object, d *duplication) {
+	if !d.include["dependencies"] {
+		return
+	}
+	for _, task := range tasks {
+		copied := s.objects[d.copies[task.str("gid")]]
+		for _, dependency := range task.refs("dependencies") {
+			if id, ok := d.copies[dependency]; ok {
+				dependency = id
+			}
+			if other := s.objects[dependency]; other != nil {
+				copied.addRef("dependencies", dependency)
+				other.addRef("dependents", copied.str("gid"))
+			}
+		}
+	}
+}
+
+func (s *Server) copyAttachment(attachment object, parentID string) {
+	copied := object{}
+	for key, value := range attachment {
+		if key != "gid" && key != "created_at" {
+			copied[key] = value
+		}
+	}
+	copied["parent"] = ref(parentID)
+	copied = s.create("attachment", copied)
+
+	if content, ok := s.files[attachment.str("gid")]; ok {
+		id := copied.str("gid")
+		s.files[id] = content
+		copied["view_url"] = fmt.Sprintf("%s/_download/%s", s.URL, id)
+		copied["permanent_url"] = fmt.Sprintf("%s/_download/%s", s.URL, id)
+	}
+}
+
+// copyProject copies a project with its sections, custom fields and tasks
+func (s *Server) copyProject(source object, name, teamID string, d *duplication, startOn, dueOn string, skipWeekends bool) (object, error) {
+	tasks := s.objectsByID(source.ids("_tasks"))
+	if err := d.schedule(s.taskTree(tasks, d), startOn, dueOn, skipWeekends); err != nil {
+		return nil, err
+	}
+
+	project := object{}
+	for key, value := range source {
+		if !strings.HasPrefix(key, "_") && key != "gid" && key != "created_at" {
+			project[key] = value
+		}
+	}
+	project["name"] = name
+	project["owner"] = ref(s.me)
+	project["members"] = []interface{}{ref(s.me)}
+	project["followers"] = []interface{}{ref(s.me)}
+	project["current_status"] = nil
+	project["current_status_update"] = nil
+	project["modified_at"] = now()
+	project["due_on"] = d.shift(source["due_on"])
+	project["start_on"] = d.shift(source["start_on"])
+	if d.include["members"] {
+		for _, member := range source.refs("members") {
+			project.addRef("members", member)
+		}
+	}
+	if !d.include["notes"] {
+		project["notes"] = ""
+		delete(project, "html_notes")
+	}
+	if teamID != "" {
+		if s.lookup(teamID, "team") == nil {
+			return nil, fmt.Errorf("team: Unknown object: %s", teamID)
+		}
+		project["team"] = ref(teamID)
+	}
+	project = s.create("project", project)
+	id := project.str("gid")
+	d.copies[source.str("gid")] = id
+
+	var sections []string
+	for _, section := range s.objectsByID(source.ids("_sections")) {
+		copied := s.create("section", object{
+			"name":    section["name"],
+			"project": ref(id),
+		})
+		sections = append(sections, copied.str("gid"))
+		d.copies[section.str("gid")] = copied.str("gid")
+	}
+	project["_sections"] = sections
+
+	var settings []string
+	for _, setting := range s.objectsByID(source.ids("_settings")) {
+		copied := s.create("custom_field_setting", object{
+			"custom_field": setting["custom_field"],
+			"parent":       ref(id),
+			"project":      ref(id),
+			"is_important": setting["is_important"],
+		})
+		settings = append(settings, copied.str("gid"))
+	}
+	project["_settings"] = settings
+
+	for _, task := range tasks {
+		if _, ok := d.copies[task.str("gid")]; ok {
+			// Already copied as a subtask
+			continue
+		}
+		if _, err := s.copyTask(task, task.str("name"), nil, d); err != nil {
+			return nil, err
+		}
+	}
+	s.copyDependencies(s.taskTree(tasks, d), d)
+	return project, nil
+}
+
+func (s *Server) duplicateProject(c *call) {
+	source := s.lookup(c.param(0), "project")
+	if source == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+	schedule, _ := c.data["schedule_dates"].(map[string]interface{})
+	if schedule != nil && (object(schedule).str("start_on") == "") == (object(schedule).str("due_on") == "") {
+		c.badRequest("schedule_dates: Exactly one of start_on and due_on is required")
+		return
+	}
+
+	job, err := s.startJob("duplicate_project", func(job object) error {
+		d := newDuplication(c.strings("include"))
+		project, err := s.copyProject(source, c.str("name"), c.str("team"), d,
+			object(schedule).str("start_on"), object(schedule).str("due_on"), schedule["should_skip_weekends"] == true)
+		if err != nil {
+			return err
+		}
+		job["new_project"] = ref(project.str("gid"))
+		return nil
+	})
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, job)
+}
+
+func (s *Server) duplicateTask(c *call) {
+	source := s.task(c)
+	if source == nil {
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+
+	job, err := s.startJob("duplicate_task", func(job object) error {
+		d := newDuplication(c.strings("include"))
+		task, err := s.copyTask(source, c.str("name"), nil, d)
+		if err != nil {
+			return err
+		}
+		s.copyDependencies(s.taskTree([]object{source}, d), d)
+		job["new_task"] = ref(task.str("gid"))
+		return nil
+	})
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, job)
+}
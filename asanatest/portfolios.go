@@ -0,0 +1,164 @@
+package asanatest
+
+// The portfolio properties which can be set directly
+var portfolioFields = []string{"name", "color", "public", "start_on", "due_on"}
+
+func (s *Server) portfolio(c *call) object {
+	portfolio := s.lookup(c.param(0), "portfolio")
+	if portfolio == nil {
+		c.notFound("portfolio", c.param(0))
+	}
+	return portfolio
+}
+
+func (s *Server) listPortfolios(c *call) {
+	workspace := c.query.Get("workspace")
+	if workspace == "" || c.query.Get("owner") == "" {
+		c.badRequest("workspace and owner are required")
+		return
+	}
+	owner := s.user(c.query.Get("owner"))
+	if owner == nil {
+		c.notFound("user", c.query.Get("owner"))
+		return
+	}
+	c.list(s, s.find("portfolio", func(portfolio object) bool {
+		return portfolio.ref("workspace") == workspace && portfolio.ref("owner") == owner.str("gid")
+	}))
+}
+
+func (s *Server) createPortfolio(c *call) {
+	workspace := c.str("workspace")
+	if s.lookup(workspace, "workspace") == nil {
+		c.badRequest("workspace: Unknown object: %s", workspace)
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+	members, err := s.userRefs(c.strings("members"))
+	if err != nil {
+		c.badRequest("members: %v", err)
+		return
+	}
+
+	portfolio := object{
+		"workspace":             ref(workspace),
+		"owner":                 ref(s.me),
+		"created_by":            ref(s.me),
+		"members":               []interface{}{ref(s.me)},
+		"color":                 "none",
+		"public":                false,
+		"start_on":              nil,
+		"due_on":                nil,
+		"current_status_update": nil,
+	}
+	for _, member := range members {
+		portfolio.addRef("members", string(member.(ref)))
+	}
+	for _, key := range portfolioFields {
+		if value, ok := c.data[key]; ok {
+			portfolio[key] = value
+		}
+	}
+	portfolio = s.create("portfolio", portfolio)
+	portfolio["permalink_url"] = s.URL + "/0/portfolio/" + portfolio.str("gid") + "/list"
+	c.created(s, portfolio)
+}
+
+func (s *Server) getPortfolio(c *call) {
+	if portfolio := s.portfolio(c); portfolio != nil {
+		c.ok(s, portfolio)
+	}
+}
+
+func (s *Server) updatePortfolio(c *call) {
+	portfolio := s.portfolio(c)
+	if portfolio == nil {
+		return
+	}
+	for _, key := range portfolioFields {
+		if value, ok := c.data[key]; ok {
+			portfolio[key] = value
+		}
+	}
+	c.ok(s, portfolio)
+}
+
+func (s *Server) deletePortfolio(c *call) {
+	portfolio := s.portfolio(c)
+	if portfolio == nil {
+		return
+	}
+	id := portfolio.str("gid")
+	for _, other := range s.find("portfolio", nil) {
+		other["_items"] = removeID(other.ids("_items"), id)
+	}
+	for _, setting := range portfolio.ids("_settings") {
+		s.remove(setting)
+	}
+	s.remove(id)
+	c.empty()
+}
+
+func (s *Server) listPortfolioItems(c *call) {
+	if portfolio := s.portfolio(c); portfolio != nil {
+		c.list(s, s.objectsByID(portfolio.ids("_items")))
+	}
+}
+
+func (s *Server) addPortfolioItem(c *call) {
+	portfolio := s.portfolio(c)
+	if portfolio == nil {
+		return
+	}
+	item := s.objects[c.str("item")]
+	if item == nil || item["resource_type"] != "project" && item["resource_type"] != "portfolio" {
+		c.notFound("item", c.str("item"))
+		return
+	}
+	if item.str("gid") == portfolio.str("gid") {
+		c.badRequest("item: A portfolio cannot contain itself")
+		return
+	}
+	portfolio["_items"] = insertID(portfolio.ids("_items"), item.str("gid"), c.str("insert_before"), c.str("insert_after"))
+	c.empty()
+}
+
+func (s *Server) removePortfolioItem(c *call) {
+	portfolio := s.portfolio(c)
+	if portfolio == nil {
+		return
+	}
+	portfolio["_items"] = removeID(portfolio.ids("_items"), c.str("item"))
+	c.empty()
+}
+
+func (s *Server) addPortfolioMembers(c *call) {
+	s.changePortfolioMembers(c, true)
+}
+
+func (s *Server) removePortfolioMembers(c *call) {
+	s.changePortfolioMembers(c, false)
+}
+
+func (s *Server) changePortfolioMembers(c *call, add bool) {
+	portfolio := s.portfolio(c)
+	if portfolio == nil {
+		return
+	}
+	members, err := s.userRefs(c.strings("members"))
+	if err != nil {
+		c.badRequest("members: %v", err)
+		return
+	}
+	for _, member := range members {
+		if add {
+			portfolio.addRef("members", string(member.(ref)))
+		} else {
+			portfolio.removeRef("members", string(member.(ref)))
+		}
+	}
+	c.ok(s, portfolio)
+}
@@ -0,0 +1,550 @@
+package asanatest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The project properties which can be set directly
+var projectFields = []string{
+	"name", "notes", "html_notes", "color", "archived", "public", "privacy_setting",
+	"default_view", "due_on", "start_on", "icon",
+}
+
+func (s *Server) listWorkspaceProjects(c *call) {
+	if s.lookup(c.param(0), "workspace") == nil {
+		c.notFound("workspace", c.param(0))
+		return
+	}
+	c.list(s, s.projects(c, func(project object) bool {
+		return project.ref("workspace") == c.param(0)
+	}))
+}
+
+func (s *Server) listTeamProjects(c *call) {
+	if s.lookup(c.param(0), "team") == nil {
+		c.notFound("team", c.param(0))
+		return
+	}
+	c.list(s, s.projects(c, func(project object) bool {
+		return project.ref("team") == c.param(0)
+	}))
+}
+
+// projects finds projects, applying the archived filter from the query
+func (s *Server) projects(c *call, f func(object) bool) []object {
+	archived := c.query.Get("archived")
+	return s.find("project", func(project object) bool {
+		if archived != "" && strconv.FormatBool(project["archived"] == true) != archived {
+			return false
+		}
+		return f(project)
+	})
+}
+
+func (s *Server) createTeamProject(c *call) {
+	c.data["team"] = c.param(0)
+	s.createProject(c)
+}
+
+func (s *Server) createProject(c *call) {
+	workspaceID := c.str("workspace")
+	teamID := c.str("team")
+
+	var team object
+	if teamID != "" {
+		if team = s.lookup(teamID, "team"); team == nil {
+			c.notFound("team", teamID)
+			return
+		}
+		if workspaceID == "" {
+			workspaceID = team.ref("organization")
+		}
+	}
+	if workspaceID == "" {
+		c.badRequest("workspace: Missing input")
+		return
+	}
+	workspace := s.lookup(workspaceID, "workspace")
+	if workspace == nil {
+		c.notFound("workspace", workspaceID)
+		return
+	}
+	if workspace["is_organization"] == true && team == nil {
+		c.badRequest("team: Missing input")
+		return
+	}
+
+	owner := ref(s.me)
+	if id := c.str("owner"); id != "" {
+		user := s.user(id)
+		if user == nil {
+			c.notFound("user", id)
+			return
+		}
+		owner = ref(user.str("gid"))
+	}
+
+	project := object{
+		"name":                  "",
+		"notes":                 "",
+		"color":                 nil,
+		"archived":              false,
+		"public":                false,
+		"default_view":          "list",
+		"due_on":                nil,
+		"start_on":              nil,
+		"current_status":        nil,
+		"current_status_update": nil,
+		"workspace":             ref(workspaceID),
+		"owner":                 owner,
+		"members":               []interface{}{owner},
+		"followers":             []interface{}{owner},
+		"modified_at":           now(),
+	}
+	if team != nil {
+		project["team"] = ref(teamID)
+	}
+	for _, key := range projectFields {
+		if value, ok := c.data[key]; ok {
+			project[key] = value
+		}
+	}
+
+	project = s.create("project", project)
+	id := project.str("gid")
+
+	// New projects have a single default section
+	section := s.create("section", object{
+		"name":    "Untitled section",
+		"project": ref(id),
+	})
+	project["_sections"] = []string{section.str("gid")}
+
+	c.created(s, project)
+}
+
+func (s *Server) getProject(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	c.ok(s, project)
+}
+
+func (s *Server) updateProject(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+
+	if id := c.str("owner"); id != "" {
+		user := s.user(id)
+		if user == nil {
+			c.notFound("user", id)
+			return
+		}
+		project["owner"] = ref(user.str("gid"))
+	}
+	for _, key := range projectFields {
+		if value, ok := c.data[key]; ok {
+			project[key] = value
+		}
+	}
+	project["modified_at"] = now()
+	c.ok(s, project)
+}
+
+func (s *Server) deleteProject(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+
+	id := project.str("gid")
+	for _, task := range s.find("task", nil) {
+		s.removeMembership(task, id)
+	}
+	for _, section := range project.ids("_sections") {
+		s.remove(section)
+	}
+	for _, setting := range project.ids("_settings") {
+		s.remove(setting)
+	}
+	s.remove(id)
+	c.empty()
+}
+
+func (s *Server) listSections(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	c.list(s, s.objectsByID(project.ids("_sections")))
+}
+
+func (s *Server) createSection(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+
+	section := s.create("section", object{
+		"name":    c.str("name"),
+		"project": ref(project.str("gid")),
+	})
+	project["_sections"] = insertID(project.ids("_sections"), section.str("gid"), c.str("insert_before"), c.str("insert_after"))
+	c.created(s, section)
+}
+
+func (s *Server) insertSection(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+
+	sections := project.ids("_sections")
+	id, before, after := c.str("section"), c.str("before_section"), c.str("after_section")
+	if !containsID(sections, id) {
+		c.badRequest("section: Section %s is not in project %s", id, project.str("gid"))
+		return
+	}
+	if (before == "") == (after == "") {
+		c.badRequest("Exactly one of before_section or after_section must be specified")
+		return
+	}
+	if !containsID(sections, before+after) {
+		c.badRequest("Section %s is not in project %s", before+after, project.str("gid"))
+		return
+	}
+
+	project["_sections"] = insertID(sections, id, before, after)
+	c.empty()
+}
+
+func (s *Server) getSection(c *call) {
+	section := s.lookup(c.param(0), "section")
+	if section == nil {
+		c.notFound("section", c.param(0))
+		return
+	}
+	c.ok(s, section)
+}
+
+func (s *Server) updateSection(c *call) {
+	section := s.lookup(c.param(0), "section")
+	if section == nil {
+		c.notFound("section", c.param(0))
+		return
+	}
+
+	if c.has("name") {
+		if c.str("name") == "" {
+			c.badRequest("name: Section name cannot be empty")
+			return
+		}
+		section["name"] = c.str("name")
+	}
+	if before, after := c.str("insert_before"), c.str("insert_after"); before != "" || after != "" {
+		project := s.objects[section.ref("project")]
+		project["_sections"] = insertID(project.ids("_sections"), section.str("gid"), before, after)
+	}
+	c.ok(s, section)
+}
+
+func (s *Server) deleteSection(c *call) {
+	section := s.lookup(c.param(0), "section")
+	if section == nil {
+		c.notFound("section", c.param(0))
+		return
+	}
+
+	id := section.str("gid")
+	project := s.objects[section.ref("project")]
+	if len(s.sectionTasks(id)) > 0 {
+		c.badRequest("Sections must be empty to be deleted")
+		return
+	}
+	if len(project.ids("_sections")) == 1 {
+		c.badRequest("The last remaining section cannot be deleted")
+		return
+	}
+
+	project["_sections"] = removeID(project.ids("_sections"), id)
+	s.remove(id)
+	c.empty()
+}
+
+func (s *Server) addTaskToSection(c *call) {
+	section := s.lookup(c.param(0), "section")
+	if section == nil {
+		c.notFound("section", c.param(0))
+		return
+	}
+	task := s.lookup(c.str("task"), "task")
+	if task == nil {
+		c.notFound("task", c.str("task"))
+		return
+	}
+
+	before, after := c.str("insert_before"), c.str("insert_after")
+	if before != "" && after != "" {
+		c.badRequest("Only one of insert_before or insert_after may be specified")
+		return
+	}
+	for _, other := range []string{before, after} {
+		if other != "" && !containsID(s.sectionTasks(section.str("gid")), other) {
+			c.badRequest("Task %s is not in section %s", other, section.str("gid"))
+			return
+		}
+	}
+
+	if err := s.addMembership(task, section.ref("project"), section.str("gid"), before, after); err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.empty()
+}
+
+// sectionTasks returns the IDs of the tasks in a section, in order
+func (s *Server) sectionTasks(sectionID string) []string {
+	section := s.objects[sectionID]
+	project := s.objects[section.ref("project")]
+
+	var result []string
+	for _, id := range project.ids("_tasks") {
+		task := s.objects[id]
+		for _, membership := range task.memberships() {
+			if membership["section"] == ref(sectionID) {
+				result = append(result, id)
+			}
+		}
+	}
+	return result
+}
+
+// projectTasks returns the tasks in a project, ordered by section
+func (s *Server) projectTasks(project object) []object {
+	var result []object
+	seen := make(map[string]bool)
+	for _, section := range project.ids("_sections") {
+		for _, id := range s.sectionTasks(section) {
+			result = append(result, s.objects[id])
+			seen[id] = true
+		}
+	}
+	for _, task := range s.objectsByID(project.ids("_tasks")) {
+		if !seen[task.str("gid")] {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+// addMembership adds a task to a project, or moves it within the project.
+// If the section is empty the task is added to the first section of the
+// project, or the section of the task it is inserted next to.
+func (s *Server) addMembership(task object, projectID, sectionID, before, after string) error {
+	project := s.lookup(projectID, "project")
+	if project == nil {
+		return fmt.Errorf("project: Unknown object: %s", projectID)
+	}
+	if task.ref("workspace") != project.ref("workspace") {
+		return fmt.Errorf("project: Task and project are in different workspaces")
+	}
+
+	if sectionID != "" {
+		section := s.lookup(sectionID, "section")
+		if section == nil || section.ref("project") != projectID {
+			return fmt.Errorf("section: Section %s is not in project %s", sectionID, projectID)
+		}
+	} else if other := s.objects[before+after]; other != nil {
+		for _, membership := range other.memberships() {
+			if membership["project"] == ref(projectID) {
+				sectionID = string(membership["section"].(ref))
+			}
+		}
+	}
+	if sectionID == "" {
+		if sections := project.ids("_sections"); len(sections) > 0 {
+			sectionID = sections[0]
+		}
+	}
+
+	var memberships []interface{}
+	for _, membership := range task.memberships() {
+		if membership["project"] != ref(projectID) {
+			memberships = append(memberships, membership)
+		}
+	}
+	membership := object{"project": ref(projectID)}
+	if sectionID != "" {
+		membership["section"] = ref(sectionID)
+	}
+	task["memberships"] = append(memberships, membership)
+
+	project["_tasks"] = insertID(project.ids("_tasks"), task.str("gid"), before, after)
+	return nil
+}
+
+// removeMembership removes a task from a project
+func (s *Server) removeMembership(task object, projectID string) {
+	memberships := []interface{}{}
+	for _, membership := range task.memberships() {
+		if membership["project"] != ref(projectID) {
+			memberships = append(memberships, membership)
+		}
+	}
+	task["memberships"] = memberships
+
+	if project := s.objects[projectID]; project != nil {
+		project["_tasks"] = removeID(project.ids("_tasks"), task.str("gid"))
+	}
+}
+
+// settingsContainer returns the project or portfolio whose custom field
+// settings are being changed
+func (s *Server) settingsContainer(c *call) object {
+	resourceType := strings.TrimSuffix(strings.Split(strings.Trim(c.r.URL.Path, "/"), "/")[0], "s")
+	container := s.lookup(c.param(0), resourceType)
+	if container == nil {
+		c.notFound(resourceType, c.param(0))
+	}
+	return container
+}
+
+func (s *Server) listCustomFieldSettings(c *call) {
+	project := s.settingsContainer(c)
+	if project == nil {
+		return
+	}
+	c.list(s, s.objectsByID(project.ids("_settings")))
+}
+
+func (s *Server) addCustomFieldSetting(c *call) {
+	project := s.settingsContainer(c)
+	if project == nil {
+		return
+	}
+
+	var field object
+	switch v := c.data["custom_field"].(type) {
+	case string:
+		if field = s.lookup(v, "custom_field"); field == nil {
+			c.notFound("custom_field", v)
+			return
+		}
+	case map[string]interface{}:
+		// A new field local to the project
+		definition := object(v)
+		definition["workspace"] = string(project.ref("workspace"))
+		var err error
+		if field, err = s.newCustomField(definition); err != nil {
+			c.badRequest("%v", err)
+			return
+		}
+		field["is_global_to_workspace"] = false
+	default:
+		c.badRequest("custom_field: Missing input")
+		return
+	}
+
+	settings := project.ids("_settings")
+	for _, setting := range s.objectsByID(settings) {
+		if setting["custom_field"] == embed(field.str("gid")) {
+			c.badRequest("custom_field: Custom field %s is already on %s %s", field.str("gid"), project["resource_type"], project.str("gid"))
+			return
+		}
+	}
+
+	setting := s.create("custom_field_setting", object{
+		"custom_field": embed(field.str("gid")),
+		"parent":       ref(project.str("gid")),
+		"is_important": c.data["is_important"] == true,
+	})
+	if project["resource_type"] == "project" {
+		setting["project"] = ref(project.str("gid"))
+	}
+	project["_settings"] = insertID(settings, setting.str("gid"), c.str("insert_before"), c.str("insert_after"))
+	c.respond(200, s.render(setting, c.fields))
+}
+
+func (s *Server) removeCustomFieldSetting(c *call) {
+	project := s.settingsContainer(c)
+	if project == nil {
+		return
+	}
+
+	for _, setting := range s.objectsByID(project.ids("_settings")) {
+		if setting["custom_field"] == embed(c.str("custom_field")) {
+			project["_settings"] = removeID(project.ids("_settings"), setting.str("gid"))
+			s.remove(setting.str("gid"))
+			c.empty()
+			return
+		}
+	}
+	c.badRequest("custom_field: Custom field %s is not on %s %s", c.str("custom_field"), project["resource_type"], project.str("gid"))
+}
+
+func (s *Server) addProjectFollowers(c *call) {
+	s.changeProjectUsers(c, "followers", true)
+}
+
+func (s *Server) removeProjectFollowers(c *call) {
+	s.changeProjectUsers(c, "followers", false)
+}
+
+func (s *Server) addProjectMembers(c *call) {
+	s.changeProjectUsers(c, "members", true)
+}
+
+func (s *Server) removeProjectMembers(c *call) {
+	s.changeProjectUsers(c, "members", false)
+}
+
+// changeProjectUsers updates the followers or members of a project. Followers
+// are always members, so adding a follower adds a member and removing a
+// member removes a follower.
+func (s *Server) changeProjectUsers(c *call, key string, add bool) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	ids := c.strings(key)
+	if len(ids) == 0 {
+		c.badRequest("%s: Missing input", key)
+		return
+	}
+	users, err := s.userRefs(ids)
+	if err != nil {
+		c.badRequest("%s: %v", key, err)
+		return
+	}
+
+	for _, user := range users {
+		id := string(user.(ref))
+		switch {
+		case add:
+			project.addRef(key, id)
+			project.addRef("members", id)
+		case key == "members":
+			project.removeRef("members", id)
+			project.removeRef("followers", id)
+		default:
+			project.removeRef("followers", id)
+		}
+	}
+	c.ok(s, project)
+}
@@ -0,0 +1,413 @@
+package asanatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"golang.org/x/oauth2"
+)
+
+// RecorderMode selects whether a Recorder captures or replays interactions
+type RecorderMode int
+
+const (
+	// Replay serves responses from the cassette without network access
+	Replay RecorderMode = iota
+
+	// Record sends requests to the real API and captures the interactions
+	Record
+)
+
+// DefaultScrubbedFields are the JSON properties replaced in recorded request
+// and response bodies, with the value used in their place
+var DefaultScrubbedFields = map[string]interface{}{
+	"email":          "user@example.com",
+	"photo":          nil,
+	"image_url":      nil,
+	"vacation_dates": nil,
+}
+
+// The response headers which are recorded. Other headers, which may include
+// cookies or details of the account, are dropped.
+var recordedHeaders = []string{
+	"Content-Type", "Retry-After", "Asana-Change", "X-Asana-Request-Id",
+}
+
+// A Cassette holds the recorded interactions for a test
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// An Interaction is a recorded request and its response
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+// RecordedRequest describes a request in enough detail to match it on replay
+type RecordedRequest struct {
+	Method string `json:"method"`
+
+	// The URL path, with any query parameters other than opt_fields
+	Path string `json:"path"`
+
+	// The requested fields, sorted, whether given as the opt_fields query
+	// parameter or in the options of the request body
+	Fields string `json:"fields,omitempty"`
+
+	// The canonical JSON request body with the options removed. Multipart
+	// uploads are not recorded.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+
+	// The body of a JSON response
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// The body of any other response, such as an attachment download
+	RawBody []byte `json:"raw_body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper which records API interactions to a
+// cassette file, or replays them from one.
+//
+// In Record mode each request is passed to Transport, and the request and
+// response are saved with the Authorization header and personal information
+// removed. In Replay mode each request is matched against the unused
+// interactions on its method, path and query, requested fields and body, and
+// the recorded response returned. Requests with no match fail with an error.
+type Recorder struct {
+	// The transport used to make real requests in Record mode. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// JSON properties whose values are replaced when recording. Defaults to
+	// DefaultScrubbedFields.
+	ScrubbedFields map[string]interface{}
+
+	mode     RecorderMode
+	path     string
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder using the cassette file at path. In Replay
+// mode the cassette is loaded immediately, and must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+	}
+	if mode == Record {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, r.cassette); err != nil {
+		return nil, fmt.Errorf("load cassette %s: %v", path, err)
+	}
+
+	// Bodies are indented in the file, and may have been edited by hand
+	for _, interaction := range r.cassette.Interactions {
+		if body := interaction.Request.Body; len(body) > 0 {
+			var data interface{}
+			if err := json.Unmarshal(body, &data); err != nil {
+				return nil, fmt.Errorf("load cassette %s: %v", path, err)
+			}
+			interaction.Request.Body, _ = json.Marshal(data)
+		}
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// NewReplayClient returns a client which replays the cassette at path,
+// failing the test if it cannot be loaded
+func NewReplayClient(t testing.TB, path string) *asana.Client {
+	t.Helper()
+	r, err := NewRecorder(path, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return asana.NewClient(&http.Client{Transport: r})
+}
+
+// NewRecordingClient returns a client which uses accessToken to make
+// requests to the real API, saving the interactions to the cassette at path
+// when the test completes
+func NewRecordingClient(t testing.TB, path, accessToken string) *asana.Client {
+	t.Helper()
+	r, _ := NewRecorder(path, Record)
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// The token is added outside the recorder, which discards it
+	transport := &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken}),
+		Base:   r,
+	}
+	return asana.NewClient(&http.Client{Transport: transport})
+}
+
+// Mode returns whether the recorder is recording or replaying
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Cassette returns the interactions recorded or loaded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{Interactions: append([]*Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Unused returns the recorded interactions which have not been replayed,
+// which usually means the code under test has changed
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []*Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			result = append(result, interaction)
+		}
+	}
+	return result
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing in Replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0644)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, body, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, recorded)
+	}
+
+	// Send the original request with a fresh copy of the body
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.recordResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{Request: recorded, Response: response})
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return response.httpResponse(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] && interaction.Request.matches(recorded) {
+			r.used[i] = true
+			return interaction.Response.httpResponse(req), nil
+		}
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", recorded.Method, recorded.Path, r.path)
+}
+
+func (a *RecordedRequest) matches(b *RecordedRequest) bool {
+	return a.Method == b.Method && a.Path == b.Path && a.Fields == b.Fields &&
+		bytes.Equal(a.Body, b.Body)
+}
+
+// recordRequest returns the matching details of a request, and its body if
+// it was read
+func (r *Recorder) recordRequest(req *http.Request) (*RecordedRequest, []byte, error) {
+	recorded := &RecordedRequest{Method: req.Method}
+
+	q := req.URL.Query()
+	fields := splitFields(q.Get("opt_fields"))
+	q.Del("opt_fields")
+	recorded.Path = req.URL.Path
+	if len(q) > 0 {
+		recorded.Path += "?" + q.Encode()
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, nil, err
+		}
+		req.Body.Close()
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if len(body) > 0 && mediaType == "application/json" {
+		var envelope map[string]interface{}
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, nil, fmt.Errorf("record request body: %v", err)
+		}
+		if options, ok := envelope["options"].(map[string]interface{}); ok {
+			if list, ok := options["fields"].([]interface{}); ok {
+				for _, field := range list {
+					fields = append(fields, fmt.Sprint(field))
+				}
+			}
+			delete(envelope, "options")
+		}
+
+		// Maps are marshalled with sorted keys, giving a canonical form
+		canonical, err := json.Marshal(r.scrub(envelope))
+		if err != nil {
+			return nil, nil, err
+		}
+		recorded.Body = canonical
+	}
+
+	sort.Strings(fields)
+	recorded.Fields = strings.Join(fields, ",")
+	return recorded, body, nil
+}
+
+func splitFields(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (r *Recorder) recordResponse(resp *http.Response) (*RecordedResponse, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := &RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+	}
+	for _, header := range recordedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			recorded.Headers[header] = value
+		}
+	}
+
+	var data interface{}
+	if len(body) > 0 && json.Unmarshal(body, &data) == nil {
+		if recorded.Body, err = json.Marshal(r.scrub(data)); err != nil {
+			return nil, err
+		}
+	} else {
+		recorded.RawBody = body
+	}
+	return recorded, nil
+}
+
+// scrub replaces the values of the scrubbed fields anywhere in a decoded
+// JSON value
+func (r *Recorder) scrub(value interface{}) interface{} {
+	fields := r.ScrubbedFields
+	if fields == nil {
+		fields = DefaultScrubbedFields
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if replacement, ok := fields[key]; ok && item != nil {
+				v[key] = replacement
+			} else {
+				v[key] = r.scrub(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.scrub(item)
+		}
+	}
+	return value
+}
+
+func (resp *RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	body := []byte(resp.Body)
+	if resp.RawBody != nil {
+		body = resp.RawBody
+	}
+
+	header := make(http.Header)
+	for key, value := range resp.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
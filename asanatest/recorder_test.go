@@ -0,0 +1,87 @@
+package asanatest_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+	"golang.org/x/oauth2"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	server := asanatest.NewServer()
+	workspace := server.AddWorkspace("Workspace")
+
+	// Run the same requests while recording and replaying
+	run := func(client *asana.Client, fields ...string) (*asana.Project, *asana.User) {
+		client.BaseURL, _ = url.Parse(server.URL)
+		project, err := client.CreateProject(&asana.CreateProjectRequest{
+			ProjectBase: asana.ProjectBase{Name: "Project"},
+			Workspace:   workspace.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fetched := &asana.Project{ID: project.ID}
+		if err := fetched.Fetch(client, &asana.Options{Fields: fields}); err != nil {
+			t.Fatal(err)
+		}
+		me := &asana.User{ID: "me"}
+		if err := me.Fetch(client); err != nil {
+			t.Fatal(err)
+		}
+		return fetched, me
+	}
+
+	recorder, err := asanatest.NewRecorder(path, asanatest.Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recording := asana.NewClient(&http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"}),
+		Base:   recorder,
+	}})
+	recorded, me := run(recording, "name", "owner")
+	if me.Email != "user@example.com" {
+		t.Errorf("Email not scrubbed from response: %q", me.Email)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "test@example.com"} {
+		if bytes.Contains(b, []byte(secret)) {
+			t.Errorf("Cassette contains %q", secret)
+		}
+	}
+
+	// Fields requested in a different order still match
+	replayer, err := asanatest.NewRecorder(path, asanatest.Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, _ := run(asana.NewClient(&http.Client{Transport: replayer}), "owner", "name")
+	if replayed.ID != recorded.ID || replayed.Name != "Project" {
+		t.Errorf("Unexpected replayed project %+v", replayed)
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Expected all interactions to be used, %d remain", len(unused))
+	}
+
+	// Requests which were not recorded fail
+	client := asanatest.NewReplayClient(t, path)
+	if err := (&asana.Task{ID: "1"}).Fetch(client); err == nil {
+		t.Error("Expected an error for an unrecorded request")
+	}
+}
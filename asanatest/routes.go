@@ -0,0 +1,177 @@
+package asanatest
+
+import (
+	"net/http"
+	"strings"
+)
+
+func r(method, pattern string, handle func(*Server, *call)) *route {
+	return &route{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handle:  handle,
+	}
+}
+
+// The API endpoints implemented by the server. Path parameters are marked
+// with {} and passed to the handler in order.
+var routes []*route
+
+func init() {
+	const (
+		get    = http.MethodGet
+		post   = http.MethodPost
+		put    = http.MethodPut
+		delete = http.MethodDelete
+	)
+
+	routes = []*route{
+		// Workspaces, users and teams
+		r(get, "/workspaces", (*Server).listWorkspaces),
+		r(get, "/workspaces/{}", (*Server).getWorkspace),
+		r(get, "/workspaces/{}/typeahead", (*Server).typeahead),
+		r(get, "/users", (*Server).listUsers),
+		r(get, "/users/{}", (*Server).getUser),
+		r(get, "/users/{}/favorites", (*Server).listFavorites),
+		r(get, "/users/{}/user_task_list", (*Server).getUserTaskListForUser),
+		r(get, "/user_task_lists/{}", (*Server).getUserTaskList),
+		r(get, "/user_task_lists/{}/tasks", (*Server).listUserTaskListTasks),
+		r(get, "/organizations/{}/teams", (*Server).listTeams),
+		r(get, "/teams/{}", (*Server).getTeam),
+
+		// Projects
+		r(get, "/workspaces/{}/projects", (*Server).listWorkspaceProjects),
+		r(get, "/teams/{}/projects", (*Server).listTeamProjects),
+		r(post, "/teams/{}/projects", (*Server).createTeamProject),
+		r(post, "/projects", (*Server).createProject),
+		r(get, "/projects/{}", (*Server).getProject),
+		r(put, "/projects/{}", (*Server).updateProject),
+		r(delete, "/projects/{}", (*Server).deleteProject),
+		r(get, "/projects/{}/custom_field_settings", (*Server).listCustomFieldSettings),
+		r(post, "/projects/{}/addCustomFieldSetting", (*Server).addCustomFieldSetting),
+		r(post, "/projects/{}/removeCustomFieldSetting", (*Server).removeCustomFieldSetting),
+		r(post, "/projects/{}/addFollowers", (*Server).addProjectFollowers),
+		r(post, "/projects/{}/removeFollowers", (*Server).removeProjectFollowers),
+		r(post, "/projects/{}/addMembers", (*Server).addProjectMembers),
+		r(post, "/projects/{}/removeMembers", (*Server).removeProjectMembers),
+
+		r(post, "/projects/{}/duplicate", (*Server).duplicateProject),
+
+		// Project templates and jobs
+		r(get, "/project_templates", (*Server).listProjectTemplates),
+		r(get, "/project_templates/{}", (*Server).getProjectTemplate),
+		r(post, "/project_templates/{}/instantiateProject", (*Server).instantiateProject),
+		r(get, "/teams/{}/project_templates", (*Server).listTeamProjectTemplates),
+		r(get, "/jobs/{}", (*Server).getJob),
+
+		// Portfolios
+		r(get, "/portfolios", (*Server).listPortfolios),
+		r(post, "/portfolios", (*Server).createPortfolio),
+		r(get, "/portfolios/{}", (*Server).getPortfolio),
+		r(put, "/portfolios/{}", (*Server).updatePortfolio),
+		r(delete, "/portfolios/{}", (*Server).deletePortfolio),
+		r(get, "/portfolios/{}/items", (*Server).listPortfolioItems),
+		r(post, "/portfolios/{}/addItem", (*Server).addPortfolioItem),
+		r(post, "/portfolios/{}/removeItem", (*Server).removePortfolioItem),
+		r(post, "/portfolios/{}/addMembers", (*Server).addPortfolioMembers),
+		r(post, "/portfolios/{}/removeMembers", (*Server).removePortfolioMembers),
+		r(get, "/portfolios/{}/custom_field_settings", (*Server).listCustomFieldSettings),
+		r(post, "/portfolios/{}/addCustomFieldSetting", (*Server).addCustomFieldSetting),
+		r(post, "/portfolios/{}/removeCustomFieldSetting", (*Server).removeCustomFieldSetting),
+
+		// Goals
+		r(get, "/goals", (*Server).listGoals),
+		r(post, "/goals", (*Server).createGoal),
+		r(get, "/goals/{}", (*Server).getGoal),
+		r(put, "/goals/{}", (*Server).updateGoal),
+		r(delete, "/goals/{}", (*Server).deleteGoal),
+		r(post, "/goals/{}/setMetric", (*Server).setGoalMetric),
+		r(post, "/goals/{}/setMetricCurrentValue", (*Server).setGoalMetricCurrentValue),
+		r(get, "/goals/{}/parentGoals", (*Server).listParentGoals),
+		r(post, "/goals/{}/addSupportingRelationship", (*Server).addSupportingRelationship),
+		r(post, "/goals/{}/removeSupportingRelationship", (*Server).removeSupportingRelationship),
+		r(get, "/goal_relationships", (*Server).listGoalRelationships),
+		r(get, "/goal_relationships/{}", (*Server).getGoalRelationship),
+		r(put, "/goal_relationships/{}", (*Server).updateGoalRelationship),
+		r(get, "/time_periods", (*Server).listTimePeriods),
+		r(get, "/time_periods/{}", (*Server).getTimePeriod),
+
+		// Status updates
+		r(get, "/status_updates", (*Server).listStatusUpdates),
+		r(post, "/status_updates", (*Server).createStatusUpdate),
+		r(get, "/status_updates/{}", (*Server).getStatusUpdate),
+		r(delete, "/status_updates/{}", (*Server).deleteStatusUpdate),
+
+		// Sections
+		r(get, "/projects/{}/sections", (*Server).listSections),
+		r(post, "/projects/{}/sections", (*Server).createSection),
+		r(post, "/projects/{}/sections/insert", (*Server).insertSection),
+		r(get, "/sections/{}", (*Server).getSection),
+		r(put, "/sections/{}", (*Server).updateSection),
+		r(delete, "/sections/{}", (*Server).deleteSection),
+		r(post, "/sections/{}/addTask", (*Server).addTaskToSection),
+
+		// Tasks
+		r(get, "/tasks", (*Server).queryTasks),
+		r(post, "/tasks", (*Server).createTask),
+		r(get, "/workspaces/{}/tasks/search", (*Server).searchTasks),
+		r(get, "/projects/{}/tasks", (*Server).listProjectTasks),
+		r(get, "/sections/{}/tasks", (*Server).listSectionTasks),
+		r(get, "/tags/{}/tasks", (*Server).listTagTasks),
+		r(get, "/tasks/{}", (*Server).getTask),
+		r(put, "/tasks/{}", (*Server).updateTask),
+		r(delete, "/tasks/{}", (*Server).deleteTask),
+		r(get, "/tasks/{}/subtasks", (*Server).listSubtasks),
+		r(post, "/tasks/{}/subtasks", (*Server).createSubtask),
+		r(post, "/tasks/{}/duplicate", (*Server).duplicateTask),
+		r(post, "/tasks/{}/setParent", (*Server).setParent),
+		r(post, "/tasks/{}/addProject", (*Server).addProject),
+		r(post, "/tasks/{}/removeProject", (*Server).removeProject),
+		r(get, "/tasks/{}/dependencies", (*Server).listDependencies),
+		r(get, "/tasks/{}/dependents", (*Server).listDependents),
+		r(post, "/tasks/{}/addDependencies", (*Server).addDependencies),
+		r(post, "/tasks/{}/addDependents", (*Server).addDependents),
+		r(post, "/tasks/{}/removeDependencies", (*Server).removeDependencies),
+		r(post, "/tasks/{}/removeDependents", (*Server).removeDependents),
+		r(get, "/tasks/{}/tags", (*Server).listTaskTags),
+		r(post, "/tasks/{}/addTag", (*Server).addTag),
+		r(post, "/tasks/{}/removeTag", (*Server).removeTag),
+		r(post, "/tasks/{}/addFollowers", (*Server).addTaskFollowers),
+		r(post, "/tasks/{}/removeFollowers", (*Server).removeTaskFollowers),
+
+		// Stories
+		r(get, "/tasks/{}/stories", (*Server).listStories),
+		r(post, "/tasks/{}/stories", (*Server).createStory),
+		r(get, "/stories/{}", (*Server).getStory),
+		r(put, "/stories/{}", (*Server).updateStory),
+		r(delete, "/stories/{}", (*Server).deleteStory),
+
+		// Attachments
+		r(get, "/tasks/{}/attachments", (*Server).listAttachments),
+		r(post, "/tasks/{}/attachments", (*Server).createAttachment),
+		r(get, "/attachments/{}", (*Server).getAttachment),
+		r(delete, "/attachments/{}", (*Server).deleteAttachment),
+
+		// Time tracking
+		r(get, "/tasks/{}/time_tracking_entries", (*Server).listTimeTrackingEntries),
+		r(post, "/tasks/{}/time_tracking_entries", (*Server).createTimeTrackingEntry),
+		r(get, "/time_tracking_entries/{}", (*Server).getTimeTrackingEntry),
+		r(put, "/time_tracking_entries/{}", (*Server).updateTimeTrackingEntry),
+		r(delete, "/time_tracking_entries/{}", (*Server).deleteTimeTrackingEntry),
+
+		// Tags
+		r(get, "/workspaces/{}/tags", (*Server).listTags),
+		r(post, "/workspaces/{}/tags", (*Server).createWorkspaceTag),
+		r(post, "/tags", (*Server).createTag),
+		r(get, "/tags/{}", (*Server).getTag),
+		r(put, "/tags/{}", (*Server).updateTag),
+		r(delete, "/tags/{}", (*Server).deleteTag),
+
+		// Custom fields
+		r(get, "/workspaces/{}/custom_fields", (*Server).listCustomFields),
+		r(post, "/custom_fields", (*Server).createCustomField),
+		r(get, "/custom_fields/{}", (*Server).getCustomField),
+		r(put, "/custom_fields/{}", (*Server).updateCustomField),
+		r(delete, "/custom_fields/{}", (*Server).deleteCustomField),
+	}
+}
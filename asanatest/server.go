@@ -0,0 +1,442 @@
+// Package asanatest provides an in-memory fake of the Asana API for testing
+// code built on the asana package.
+//
+// The fake implements the endpoints used by the client for workspaces,
+// users, user task lists, teams, projects, portfolios, goals, status
+// updates, project templates, jobs, sections, tasks, time tracking
+// entries, tags, stories, attachments and custom fields, keeping the state
+// of each object so that changes made through one endpoint are visible
+// through the others. List endpoints are
+// paginated with offsets in the same way as the real API, and failures such
+// as rate limiting can be injected to test error handling.
+//
+// Typical use is:
+//
+//	client, server := asanatest.NewClient(t)
+//	workspace := server.AddWorkspace("Test workspace")
+//	project, err := client.CreateProject(&asana.CreateProjectRequest{
+//		ProjectBase: asana.ProjectBase{Name: "Project"},
+//		Workspace:   workspace.ID,
+//	})
+//
+// For tests which need the payloads of the real API, a Recorder captures
+// interactions with it to a cassette file which can be replayed later
+// without network access.
+package asanatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+)
+
+// Server is a fake Asana API server backed by in-memory state. It is safe
+// for concurrent use.
+type Server struct {
+	// The base URL of the fake API
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	nextID   int64
+	objects  map[string]object
+	order    []string
+	files    map[string][]byte
+	me       string
+	faults   []*Fault
+	requests []*Request
+	failJob  bool
+
+	// Download URLs issued with an earlier key have expired
+	downloadKey int
+}
+
+// A Fault makes matching requests fail with the given status code instead of
+// being handled
+type Fault struct {
+	// The HTTP method to match, or empty to match any method
+	Method string
+
+	// The path prefix to match, such as "/tasks", or empty to match any path
+	Path string
+
+	// The status code of the error response
+	StatusCode int
+
+	// The error message returned. Defaults to the status text.
+	Message string
+
+	// The value of the Retry-After header, sent if non-zero. The header is
+	// given in whole seconds, rounding up.
+	RetryAfter time.Duration
+
+	// The number of requests to fail. Zero means all matching requests fail
+	// until the fault is cleared.
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || strings.EqualFold(f.Method, r.Method)) &&
+		strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// Request records a request received by the Server
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+
+	// The status code of the response
+	StatusCode int
+}
+
+// NewServer starts a new fake API server. The server has a single user,
+// who is the authenticated user for all requests, and no workspaces.
+func NewServer() *Server {
+	s := &Server{
+		nextID:  1000,
+		objects: make(map[string]object),
+		files:   make(map[string][]byte),
+	}
+	s.me = s.create("user", object{
+		"name":       "Test User",
+		"email":      "test@example.com",
+		"workspaces": []interface{}{},
+	})["gid"].(string)
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// NewClient starts a new Server, which is closed when the test completes,
+// and returns a client configured to use it
+func NewClient(t testing.TB) (*asana.Client, *Server) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+	return s.Client(), s
+}
+
+// Client returns a new client configured to use the server
+func (s *Server) Client() *asana.Client {
+	client := asana.NewClient(s.server.Client())
+	client.BaseURL, _ = url.Parse(s.URL)
+	return client
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Inject adds a fault which causes matching requests to fail
+func (s *Server) Inject(fault *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, fault)
+}
+
+// FailNext makes the next n requests fail with the given status code
+func (s *Server) FailNext(n, statusCode int) {
+	s.Inject(&Fault{StatusCode: statusCode, Times: n})
+}
+
+// RateLimitNext makes the next n requests fail with a 429 Too Many Requests
+// response with the given Retry-After duration
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.Inject(&Fault{
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: retryAfter,
+		Times:      n,
+	})
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received by the server, in order
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request{}, s.requests...)
+}
+
+// ServeHTTP implements the http.Handler interface
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		s.mu.Lock()
+		s.requests = append(s.requests, &Request{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Query:      r.URL.Query(),
+			StatusCode: recorder.status,
+		})
+		s.mu.Unlock()
+	}()
+
+	if fault := s.fault(r); fault != nil {
+		message := fault.Message
+		if message == "" {
+			message = http.StatusText(fault.StatusCode)
+		}
+		if fault.RetryAfter > 0 {
+			seconds := (fault.RetryAfter + time.Second - 1) / time.Second
+			recorder.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+		} else if fault.StatusCode == http.StatusTooManyRequests {
+			recorder.Header().Set("Retry-After", "0")
+		}
+		writeError(recorder, fault.StatusCode, message)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/batch" && r.Method == http.MethodPost:
+		s.batch(recorder, r)
+	case strings.HasPrefix(r.URL.Path, "/_download/") && r.Method == http.MethodGet:
+		s.download(recorder, r)
+	default:
+		s.dispatch(recorder, r)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// fault returns the first injected fault matching r, if any
+func (s *Server) fault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			if fault.Times--; fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// dispatch routes an API request to its handler
+func (s *Server) dispatch(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	methodAllowed := false
+	for _, route := range routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			methodAllowed = true
+			continue
+		}
+
+		c, err := newCall(w, r, params)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		route.handle(s, c)
+		return
+	}
+
+	if methodAllowed {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "No matching route for request")
+}
+
+type route struct {
+	method  string
+	pattern []string
+	handle  func(*Server, *call)
+}
+
+func (r *route) match(segments []string) ([]string, bool) {
+	if len(segments) != len(r.pattern) {
+		return nil, false
+	}
+	var params []string
+	for i, segment := range r.pattern {
+		if segment == "{}" {
+			params = append(params, segments[i])
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// batch executes each action of a batch request as a separate request
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Data struct {
+			Actions []struct {
+				RelativePath string          `json:"relative_path"`
+				Method       string          `json:"method"`
+				Data         json.RawMessage `json:"data"`
+				Options      json.RawMessage `json:"options"`
+			} `json:"actions"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(request.Data.Actions) > 10 {
+		writeError(w, http.StatusBadRequest, "actions: Batch requests may contain at most 10 actions")
+		return
+	}
+
+	var results []interface{}
+	for _, action := range request.Data.Actions {
+		body := fmt.Sprintf(`{"data":%s}`, nullIfEmpty(action.Data))
+		if len(action.Options) > 0 {
+			body = fmt.Sprintf(`{"data":%s,"options":%s}`, nullIfEmpty(action.Data), action.Options)
+		}
+
+		path := action.RelativePath
+		method := strings.ToUpper(action.Method)
+		if method == http.MethodGet {
+			query, err := optionsQuery(action.Options)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if query != "" {
+				path += "?" + query
+			}
+			body = ""
+		}
+
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		s.dispatch(rec, req)
+
+		var responseBody interface{}
+		json.Unmarshal(rec.Body.Bytes(), &responseBody)
+		headers := map[string]string{}
+		for key := range rec.Header() {
+			headers[key] = rec.Header().Get(key)
+		}
+		results = append(results, map[string]interface{}{
+			"status_code": rec.Code,
+			"headers":     headers,
+			"body":        responseBody,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": results})
+}
+
+func nullIfEmpty(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return json.RawMessage("null")
+	}
+	return data
+}
+
+// optionsQuery converts the options of a batch action to query parameters
+func optionsQuery(options json.RawMessage) (string, error) {
+	if len(options) == 0 {
+		return "", nil
+	}
+	var o map[string]interface{}
+	if err := json.Unmarshal(options, &o); err != nil {
+		return "", fmt.Errorf("options: Invalid options")
+	}
+
+	q := url.Values{}
+	for key, value := range o {
+		name := key
+		switch key {
+		case "fields", "expand", "pretty", "jsonp":
+			name = "opt_" + key
+		}
+		switch v := value.(type) {
+		case []interface{}:
+			var values []string
+			for _, item := range v {
+				values = append(values, fmt.Sprint(item))
+			}
+			q.Set(name, strings.Join(values, ","))
+		default:
+			q.Set(name, fmt.Sprint(v))
+		}
+	}
+	return q.Encode(), nil
+}
+
+// ExpireDownloadURLs makes the download URLs of attachments returned so far
+// invalid, as happens to real download URLs an hour after they are issued.
+// Fetching an attachment again returns a new URL.
+func (s *Server) ExpireDownloadURLs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.downloadKey++
+}
+
+// download serves the content of an uploaded attachment
+func (s *Server) download(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, ok := s.files[strings.TrimPrefix(r.URL.Path, "/_download/")]
+	expired := r.URL.Query().Get("key") != strconv.Itoa(s.downloadKey)
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if expired {
+		http.Error(w, "Request has expired", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
@@ -0,0 +1,204 @@
+package asanatest_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func setup(t *testing.T) (*asana.Client, *asanatest.Server, *asana.Project) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Project"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server, project
+}
+
+func TestTasks(t *testing.T) {
+	client, _, project := setup(t)
+
+	task, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Task"},
+		Projects: []string{project.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Workspace == nil || task.Workspace.ID != project.Workspace.ID {
+		t.Errorf("Task workspace not set from project: %v", task.Workspace)
+	}
+
+	completed := true
+	if err := task.Update(client, &asana.UpdateTaskRequest{TaskBase: asana.TaskBase{Completed: &completed}}); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched := &asana.Task{ID: task.ID}
+	if err := fetched.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if !asana.IsTrue(fetched.Completed) || fetched.CompletedAt == nil {
+		t.Errorf("Task not completed: %v", fetched.CompletedAt)
+	}
+	if len(fetched.Memberships) != 1 || fetched.Memberships[0].Section == nil {
+		t.Errorf("Task not added to the default section: %v", fetched.Memberships)
+	}
+
+	if err := task.Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	err = fetched.Fetch(client)
+	if e, ok := asana.IsAsanaError(err); !ok || e.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected not found error, but saw %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	client, server, project := setup(t)
+
+	for i := 0; i < 25; i++ {
+		if _, err := client.CreateTask(&asana.CreateTaskRequest{
+			TaskBase: asana.TaskBase{Name: fmt.Sprintf("Task %d", i)},
+			Projects: []string{project.ID},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	it := project.IterateTasks(context.Background(), client)
+	it.PageSize = 10
+	tasks, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 25 {
+		t.Fatalf("Expected 25 tasks, but saw %d", len(tasks))
+	}
+	for i, task := range tasks {
+		if expected := fmt.Sprintf("Task %d", i); task.Name != expected {
+			t.Errorf("Task %d: expected %q, but saw %q", i, expected, task.Name)
+		}
+	}
+
+	pages := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/projects/"+project.ID+"/tasks" {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 page requests, but saw %d", pages)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	client, server, project := setup(t)
+	client.Retry = &asana.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	server.RateLimitNext(1, time.Millisecond)
+	server.FailNext(1, http.StatusBadGateway)
+	if err := project.Fetch(client); err != nil {
+		t.Fatalf("Expected retries to succeed: %v", err)
+	}
+
+	server.FailNext(3, http.StatusServiceUnavailable)
+	err := project.Fetch(client)
+	if e, ok := asana.IsAsanaError(err); !ok || e.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected service unavailable error, but saw %v", err)
+	}
+
+	client.Retry = nil
+	server.RateLimitNext(1, 30*time.Second)
+	err = project.Fetch(client)
+	if !asana.IsRateLimited(err) || asana.RetryAfter(err) != 30*time.Second {
+		t.Errorf("Expected rate limit error with Retry-After, but saw %v", err)
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	client, _, project := setup(t)
+	task, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Task"},
+		Projects: []string{project.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("file content")
+	attachment, err := task.CreateAttachment(client, &asana.NewAttachment{
+		Reader:      io.NopCloser(bytes.NewReader(content)),
+		FileName:    "file.txt",
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.Name != "file.txt" {
+		t.Errorf("Unexpected attachment name %q", attachment.Name)
+	}
+
+	resp, err := http.Get(attachment.DownloadURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	downloaded, _ := io.ReadAll(resp.Body)
+	if !bytes.Equal(downloaded, content) {
+		t.Errorf("Downloaded %q, expected %q", downloaded, content)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	client, server, project := setup(t)
+
+	batch := asana.NewBatch()
+	var results []*asana.BatchResult
+	for i := 0; i < 12; i++ {
+		results = append(results, batch.CreateTask(&asana.CreateTaskRequest{
+			TaskBase: asana.TaskBase{Name: fmt.Sprintf("Task %d", i)},
+			Projects: []string{project.ID},
+		}))
+	}
+	missing := batch.Get("/tasks/0")
+	if _, err := batch.Execute(client); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range results {
+		if err := result.Err(); err != nil {
+			t.Error(err)
+		}
+	}
+	if e, ok := asana.IsAsanaError(missing.Err()); !ok || e.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected not found error, but saw %v", missing.Err())
+	}
+
+	tasks, _, err := project.Tasks(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 12 {
+		t.Errorf("Expected 12 tasks, but saw %d", len(tasks))
+	}
+
+	task := &asana.Task{}
+	if err := server.Decode(tasks[0].ID, task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Name != "Task 0" {
+		t.Errorf("Unexpected task name %q", task.Name)
+	}
+}
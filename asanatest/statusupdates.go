@@ -0,0 +1,112 @@
+package asanatest
+
+import (
+	"time"
+)
+
+var statusTypes = map[string]bool{
+	"on_track": true, "at_risk": true, "off_track": true, "on_hold": true, "complete": true,
+	"achieved": true, "partial": true, "missed": true, "dropped": true,
+}
+
+// statusUpdates returns the status updates posted on an object, newest first
+func (s *Server) statusUpdates(parent string) []object {
+	updates := s.find("status_update", func(update object) bool {
+		return update.ref("parent") == parent
+	})
+	for i, j := 0, len(updates)-1; i < j; i, j = i+1, j-1 {
+		updates[i], updates[j] = updates[j], updates[i]
+	}
+	return updates
+}
+
+func (s *Server) createStatusUpdate(c *call) {
+	parent := s.objects[c.str("parent")]
+	if parent == nil {
+		c.badRequest("parent: Unknown object: %s", c.str("parent"))
+		return
+	}
+	resourceType, _ := parent["resource_type"].(string)
+	if resourceType != "project" && resourceType != "portfolio" && resourceType != "goal" {
+		c.badRequest("parent: Status updates cannot be posted on a %s", resourceType)
+		return
+	}
+	if !statusTypes[c.str("status_type")] {
+		c.badRequest("status_type: Invalid status type: %s", c.str("status_type"))
+		return
+	}
+	if c.str("text") == "" && c.str("html_text") == "" {
+		c.badRequest("text: Missing input")
+		return
+	}
+
+	update := s.create("status_update", object{
+		"resource_subtype": resourceType + "_status_update",
+		"parent":           ref(parent.str("gid")),
+		"title":            c.str("title"),
+		"text":             c.str("text"),
+		"html_text":        c.str("html_text"),
+		"status_type":      c.str("status_type"),
+		"author":           ref(s.me),
+		"created_by":       ref(s.me),
+		"modified_at":      now(),
+		"num_likes":        0,
+	})
+	parent["current_status_update"] = ref(update.str("gid"))
+	c.created(s, update)
+}
+
+func (s *Server) listStatusUpdates(c *call) {
+	parent := c.query.Get("parent")
+	if s.objects[parent] == nil {
+		c.badRequest("parent: Missing input")
+		return
+	}
+	updates := s.statusUpdates(parent)
+	if since := c.query.Get("created_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.badRequest("created_since: Invalid date-time: %s", since)
+			return
+		}
+		var filtered []object
+		for _, update := range updates {
+			if createdAt, _ := update["created_at"].(time.Time); createdAt.After(t) {
+				filtered = append(filtered, update)
+			}
+		}
+		updates = filtered
+	}
+	c.list(s, updates)
+}
+
+func (s *Server) statusUpdate(c *call) object {
+	update := s.lookup(c.param(0), "status_update")
+	if update == nil {
+		c.notFound("status_update", c.param(0))
+	}
+	return update
+}
+
+func (s *Server) getStatusUpdate(c *call) {
+	if update := s.statusUpdate(c); update != nil {
+		c.ok(s, update)
+	}
+}
+
+func (s *Server) deleteStatusUpdate(c *call) {
+	update := s.statusUpdate(c)
+	if update == nil {
+		return
+	}
+	s.remove(update.str("gid"))
+
+	// The previous update becomes current again
+	if parent := s.objects[update.ref("parent")]; parent != nil && parent.ref("current_status_update") == update.str("gid") {
+		parent["current_status_update"] = nil
+		if updates := s.statusUpdates(parent.str("gid")); len(updates) > 0 {
+			parent["current_status_update"] = ref(updates[0].str("gid"))
+		}
+	}
+	c.empty()
+}
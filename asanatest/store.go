@@ -0,0 +1,342 @@
+package asanatest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object holds the properties of a stored resource. Keys starting with an
+// underscore are internal state which is not returned by the API.
+type object map[string]interface{}
+
+// ref is a reference to another object, rendered in its compact form
+type ref string
+
+// embed is a reference to another object, rendered in full
+type embed string
+
+func (o object) str(key string) string {
+	s, _ := o[key].(string)
+	return s
+}
+
+func (o object) ref(key string) string {
+	r, _ := o[key].(ref)
+	return string(r)
+}
+
+// refs returns the IDs in a list of references
+func (o object) refs(key string) []string {
+	var ids []string
+	list, _ := o[key].([]interface{})
+	for _, item := range list {
+		switch v := item.(type) {
+		case ref:
+			ids = append(ids, string(v))
+		case embed:
+			ids = append(ids, string(v))
+		}
+	}
+	return ids
+}
+
+// ids returns an internal list of IDs
+func (o object) ids(key string) []string {
+	ids, _ := o[key].([]string)
+	return ids
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// create stores a new object and returns it
+func (s *Server) create(resourceType string, props object) object {
+	s.nextID++
+	id := strconv.FormatInt(s.nextID, 10)
+
+	obj := object{
+		"gid":           id,
+		"resource_type": resourceType,
+	}
+	for key, value := range props {
+		obj[key] = value
+	}
+	if _, ok := obj["created_at"]; !ok {
+		obj["created_at"] = now()
+	}
+
+	s.objects[id] = obj
+	s.order = append(s.order, id)
+	return obj
+}
+
+// lookup returns the object with the given ID and resource type, or nil.
+// Objects with external data can also be found by external:<id>.
+func (s *Server) lookup(id, resourceType string) object {
+	if external := strings.TrimPrefix(id, "external:"); external != id {
+		for _, obj := range s.find(resourceType, nil) {
+			if data, ok := obj["external"].(map[string]interface{}); ok && data["gid"] == external {
+				return obj
+			}
+		}
+		return nil
+	}
+	obj := s.objects[id]
+	if obj == nil || obj["resource_type"] != resourceType {
+		return nil
+	}
+	return obj
+}
+
+// remove deletes an object
+func (s *Server) remove(id string) {
+	delete(s.objects, id)
+	for i, other := range s.order {
+		if other == id {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// find returns the objects of a resource type matching f, in the order they
+// were created
+func (s *Server) find(resourceType string, f func(object) bool) []object {
+	var result []object
+	for _, id := range s.order {
+		obj := s.objects[id]
+		if obj["resource_type"] == resourceType && (f == nil || f(obj)) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// objectsByID returns the objects for a list of IDs, skipping any which
+// have been deleted
+func (s *Server) objectsByID(ids []string) []object {
+	var result []object
+	for _, id := range ids {
+		if obj := s.objects[id]; obj != nil {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// compact renders the compact representation of an object
+func (s *Server) compact(id string) map[string]interface{} {
+	obj := s.objects[id]
+	if obj == nil {
+		return map[string]interface{}{"gid": id}
+	}
+
+	result := map[string]interface{}{
+		"gid":           id,
+		"resource_type": obj["resource_type"],
+	}
+	for _, key := range []string{"name", "resource_subtype"} {
+		if value, ok := obj[key]; ok {
+			result[key] = value
+		}
+	}
+	switch obj["resource_type"] {
+	case "custom_field_setting":
+		result["custom_field"] = s.resolve(obj["custom_field"])
+	case "goal_relationship":
+		result["supporting_resource"] = s.resolve(obj["supporting_resource"])
+		result["contribution_weight"] = obj["contribution_weight"]
+	case "time_tracking_entry":
+		result["duration_minutes"] = obj["duration_minutes"]
+		result["entered_on"] = obj["entered_on"]
+		result["created_by"] = s.resolve(obj["created_by"])
+	}
+	return result
+}
+
+// render returns the full representation of an object, restricted to the
+// given fields if any are provided
+func (s *Server) render(obj object, fields []string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range obj {
+		if !strings.HasPrefix(key, "_") {
+			result[key] = value
+		}
+	}
+	s.addComputed(obj, result)
+
+	for key, value := range result {
+		result[key] = s.resolve(value)
+	}
+	return filterFields(result, fields)
+}
+
+// resolve renders the references in a property value
+func (s *Server) resolve(value interface{}) interface{} {
+	switch v := value.(type) {
+	case ref:
+		return s.compact(string(v))
+	case embed:
+		if obj := s.objects[string(v)]; obj != nil {
+			return s.render(obj, nil)
+		}
+		return map[string]interface{}{"gid": string(v)}
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = s.resolve(item)
+		}
+		return list
+	case object:
+		m := make(map[string]interface{})
+		for key, item := range v {
+			m[key] = s.resolve(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for key, item := range v {
+			m[key] = s.resolve(item)
+		}
+		return m
+	default:
+		return value
+	}
+}
+
+// filterFields keeps the gid and the requested fields. Nested field paths
+// such as memberships.section.name select the whole top level field.
+func filterFields(result map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return result
+	}
+
+	filtered := map[string]interface{}{"gid": result["gid"]}
+	for _, field := range fields {
+		field = strings.SplitN(field, ".", 2)[0]
+		if value, ok := result[field]; ok {
+			filtered[field] = value
+		}
+	}
+	return filtered
+}
+
+// addComputed adds the properties which are derived from other objects
+func (s *Server) addComputed(obj object, result map[string]interface{}) {
+	switch obj["resource_type"] {
+	case "task":
+		var projects []interface{}
+		for _, membership := range obj.memberships() {
+			projects = append(projects, membership["project"])
+		}
+		result["projects"] = nonNil(projects)
+		result["num_subtasks"] = len(s.objectsByID(obj.ids("_subtasks")))
+		result["custom_fields"] = s.taskCustomFields(obj)
+		result["actual_time_minutes"] = s.actualTime(obj)
+
+	case "project", "portfolio":
+		var settings []interface{}
+		for _, id := range obj.ids("_settings") {
+			if s.objects[id] != nil {
+				settings = append(settings, ref(id))
+			}
+		}
+		result["custom_field_settings"] = nonNil(settings)
+
+	case "attachment":
+		if _, ok := s.files[obj.str("gid")]; ok {
+			result["download_url"] = s.downloadURL(obj.str("gid"))
+		}
+	}
+}
+
+func nonNil(list []interface{}) []interface{} {
+	if list == nil {
+		return []interface{}{}
+	}
+	return list
+}
+
+// memberships returns the project memberships of a task
+func (o object) memberships() []object {
+	var result []object
+	list, _ := o["memberships"].([]interface{})
+	for _, item := range list {
+		if m, ok := item.(object); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// insertID inserts id into list before or after another ID. If neither is
+// given, or the other ID is not in the list, id is appended.
+func insertID(list []string, id, before, after string) []string {
+	list = removeID(list, id)
+
+	index := len(list)
+	for i, other := range list {
+		if before != "" && other == before {
+			index = i
+		} else if after != "" && other == after {
+			index = i + 1
+		}
+	}
+
+	list = append(list, "")
+	copy(list[index+1:], list[index:])
+	list[index] = id
+	return list
+}
+
+func removeID(list []string, id string) []string {
+	result := make([]string, 0, len(list))
+	for _, other := range list {
+		if other != id {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
+func containsID(list []string, id string) bool {
+	for _, other := range list {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// addRef adds a reference to a list property if it is not already present
+func (o object) addRef(key, id string) {
+	if containsID(o.refs(key), id) {
+		return
+	}
+	list, _ := o[key].([]interface{})
+	o[key] = append(list, ref(id))
+}
+
+// removeRef removes a reference from a list property
+func (o object) removeRef(key, id string) {
+	list, _ := o[key].([]interface{})
+	result := []interface{}{}
+	for _, item := range list {
+		if item != ref(id) {
+			result = append(result, item)
+		}
+	}
+	o[key] = result
+}
+
+// sortByCreation orders objects by their creation time, newest first
+func sortByCreation(objects []object) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, _ := objects[i]["created_at"].(time.Time)
+		b, _ := objects[j]["created_at"].(time.Time)
+		return a.After(b)
+	})
+}
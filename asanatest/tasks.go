@@ -0,0 +1,946 @@
+package asanatest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The task properties which can be set directly
+var taskFields = []string{
+	"name", "notes", "html_notes", "resource_subtype", "assignee_status",
+	"due_on", "due_at", "start_on", "start_at", "liked", "external",
+	"is_rendered_as_separator", "approval_status",
+}
+
+// newTask creates a task from the data of a create request
+func (s *Server) newTask(c *call, parent object) (object, error) {
+	task := object{
+		"name":             "",
+		"notes":            "",
+		"resource_subtype": "default_task",
+		"completed":        false,
+		"completed_at":     nil,
+		"assignee":         nil,
+		"assignee_status":  "upcoming",
+		"assignee_section": nil,
+		"due_on":           nil,
+		"due_at":           nil,
+		"start_on":         nil,
+		"parent":           nil,
+		"memberships":      []interface{}{},
+		"tags":             []interface{}{},
+		"followers":        []interface{}{ref(s.me)},
+		"dependencies":     []interface{}{},
+		"dependents":       []interface{}{},
+		"_custom_fields":   map[string]interface{}{},
+	}
+
+	// Find the workspace from the task's containers
+	workspace := c.str("workspace")
+	projects := c.strings("projects")
+	var memberships []object
+	if list, ok := c.data["memberships"].([]interface{}); ok {
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok {
+				memberships = append(memberships, object(m))
+			}
+		}
+	}
+	if parent != nil {
+		workspace = parent.ref("workspace")
+		task["parent"] = ref(parent.str("gid"))
+	} else if id := c.str("parent"); id != "" {
+		if parent = s.lookup(id, "task"); parent == nil {
+			return nil, fmt.Errorf("parent: Unknown object: %s", id)
+		}
+		workspace = parent.ref("workspace")
+		task["parent"] = ref(id)
+	}
+	if workspace == "" {
+		for _, id := range append(projects, c.strings("tags")...) {
+			if obj := s.objects[id]; obj != nil {
+				workspace = obj.ref("workspace")
+			}
+		}
+		for _, m := range memberships {
+			if obj := s.objects[m.str("project")]; obj != nil {
+				workspace = obj.ref("workspace")
+			}
+		}
+	}
+	if workspace == "" {
+		return nil, fmt.Errorf("workspace: Missing input")
+	}
+	if s.lookup(workspace, "workspace") == nil {
+		return nil, fmt.Errorf("workspace: Unknown object: %s", workspace)
+	}
+	task["workspace"] = ref(workspace)
+
+	if err := s.applyTask(task, c); err != nil {
+		return nil, err
+	}
+
+	if followers := c.strings("followers"); len(followers) > 0 {
+		refs, err := s.userRefs(followers)
+		if err != nil {
+			return nil, err
+		}
+		for _, follower := range refs {
+			task.addRef("followers", string(follower.(ref)))
+		}
+	}
+	for _, id := range c.strings("tags") {
+		if s.lookup(id, "tag") == nil {
+			return nil, fmt.Errorf("tags: Unknown object: %s", id)
+		}
+		task.addRef("tags", id)
+	}
+
+	task = s.create("task", task)
+	if parent != nil {
+		parent["_subtasks"] = append(parent.ids("_subtasks"), task.str("gid"))
+	}
+	for _, id := range projects {
+		if err := s.addMembership(task, id, "", "", ""); err != nil {
+			s.remove(task.str("gid"))
+			return nil, err
+		}
+	}
+	for _, m := range memberships {
+		if err := s.addMembership(task, m.str("project"), m.str("section"), "", ""); err != nil {
+			s.remove(task.str("gid"))
+			return nil, err
+		}
+	}
+	return task, nil
+}
+
+// applyTask sets the properties of a task from a create or update request
+func (s *Server) applyTask(task object, c *call) error {
+	for _, key := range taskFields {
+		if value, ok := c.data[key]; ok {
+			task[key] = value
+		}
+	}
+
+	if value, ok := c.data["completed"]; ok {
+		completed, _ := value.(bool)
+		if completed && task["completed"] != true {
+			task["completed_at"] = now()
+		} else if !completed {
+			task["completed_at"] = nil
+		}
+		task["completed"] = completed
+	}
+
+	if c.has("assignee") {
+		previous := task.ref("assignee")
+		task["assignee"] = nil
+		if id := c.str("assignee"); id != "" {
+			user := s.user(id)
+			if user == nil {
+				return fmt.Errorf("assignee: Unknown object: %s", id)
+			}
+			task["assignee"] = ref(user.str("gid"))
+		}
+		if task.ref("assignee") == "" {
+			task["assignee_section"] = nil
+		} else if previous != task.ref("assignee") {
+			// Newly assigned tasks start in the first section of My Tasks
+			list := s.taskListFor(task.ref("assignee"), task.ref("workspace"))
+			task["assignee_section"] = ref(list.ids("_sections")[0])
+		}
+	}
+
+	if id := c.str("assignee_section"); id != "" {
+		if task.ref("assignee") == "" {
+			return fmt.Errorf("assignee_section: Task is not assigned")
+		}
+		list := s.taskListFor(task.ref("assignee"), task.ref("workspace"))
+		if !containsID(list.ids("_sections"), id) {
+			return fmt.Errorf("assignee_section: Section %s is not in the assignee's task list", id)
+		}
+		task["assignee_section"] = ref(id)
+	}
+
+	if values, ok := c.data["custom_fields"].(map[string]interface{}); ok {
+		stored := task["_custom_fields"].(map[string]interface{})
+		for id, value := range values {
+			value, err := s.customFieldValue(id, value)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				delete(stored, id)
+			} else {
+				stored[id] = value
+			}
+		}
+	}
+
+	task["modified_at"] = now()
+	return nil
+}
+
+func (s *Server) task(c *call) object {
+	task := s.lookup(c.param(0), "task")
+	if task == nil {
+		c.notFound("task", c.param(0))
+	}
+	return task
+}
+
+func (s *Server) createTask(c *call) {
+	task, err := s.newTask(c, nil)
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, task)
+}
+
+func (s *Server) getTask(c *call) {
+	if task := s.task(c); task != nil {
+		c.ok(s, task)
+	}
+}
+
+func (s *Server) updateTask(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if err := s.applyTask(task, c); err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.ok(s, task)
+}
+
+func (s *Server) deleteTask(c *call) {
+	if task := s.task(c); task != nil {
+		s.deleteTaskTree(task)
+		c.empty()
+	}
+}
+
+// deleteTaskTree deletes a task with its subtasks, stories and attachments
+func (s *Server) deleteTaskTree(task object) {
+	id := task.str("gid")
+	for _, subtask := range s.objectsByID(task.ids("_subtasks")) {
+		s.deleteTaskTree(subtask)
+	}
+	for _, membership := range task.memberships() {
+		s.removeMembership(task, string(membership["project"].(ref)))
+	}
+	if parent := s.objects[task.ref("parent")]; parent != nil {
+		parent["_subtasks"] = removeID(parent.ids("_subtasks"), id)
+	}
+	for _, other := range s.find("task", nil) {
+		other.removeRef("dependencies", id)
+		other.removeRef("dependents", id)
+	}
+	for _, obj := range s.find("story", nil) {
+		if obj.ref("target") == id {
+			s.remove(obj.str("gid"))
+		}
+	}
+	for _, obj := range s.find("time_tracking_entry", nil) {
+		if obj.ref("task") == id {
+			s.remove(obj.str("gid"))
+		}
+	}
+	for _, obj := range s.find("attachment", nil) {
+		if obj.ref("parent") == id {
+			delete(s.files, obj.str("gid"))
+			s.remove(obj.str("gid"))
+		}
+	}
+	s.remove(id)
+}
+
+func (s *Server) queryTasks(c *call) {
+	var tasks []object
+	switch {
+	case c.query.Get("project") != "":
+		project := s.lookup(c.query.Get("project"), "project")
+		if project == nil {
+			c.notFound("project", c.query.Get("project"))
+			return
+		}
+		tasks = s.projectTasks(project)
+
+	case c.query.Get("section") != "":
+		if s.lookup(c.query.Get("section"), "section") == nil {
+			c.notFound("section", c.query.Get("section"))
+			return
+		}
+		tasks = s.objectsByID(s.sectionTasks(c.query.Get("section")))
+
+	case c.query.Get("tag") != "":
+		tasks = s.find("task", func(task object) bool {
+			return containsID(task.refs("tags"), c.query.Get("tag"))
+		})
+
+	case c.query.Get("user_task_list") != "":
+		list := s.lookup(c.query.Get("user_task_list"), "user_task_list")
+		if list == nil {
+			c.notFound("user_task_list", c.query.Get("user_task_list"))
+			return
+		}
+		tasks = s.userTaskListTasks(list)
+
+	case c.query.Get("assignee") != "" && c.query.Get("workspace") != "":
+		user := s.user(c.query.Get("assignee"))
+		if user == nil {
+			c.notFound("user", c.query.Get("assignee"))
+			return
+		}
+		tasks = s.find("task", func(task object) bool {
+			return task.ref("assignee") == user.str("gid") && task.ref("workspace") == c.query.Get("workspace")
+		})
+
+	default:
+		c.badRequest("Must specify exactly one of project, tag, section, user task list, or assignee + workspace")
+		return
+	}
+
+	filtered, err := filterTasks(tasks, c.query.Get("completed_since"), c.query.Get("modified_since"))
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.list(s, filtered)
+}
+
+// filterTasks applies the completed_since and modified_since filters
+func filterTasks(tasks []object, completedSince, modifiedSince string) ([]object, error) {
+	var completed, modified time.Time
+	var err error
+	if completedSince != "" {
+		if completed, err = parseTime(completedSince); err != nil {
+			return nil, fmt.Errorf("completed_since: %v", err)
+		}
+	}
+	if modifiedSince != "" {
+		if modified, err = parseTime(modifiedSince); err != nil {
+			return nil, fmt.Errorf("modified_since: %v", err)
+		}
+	}
+
+	var result []object
+	for _, task := range tasks {
+		if completedSince != "" && task["completed"] == true {
+			// "now" returns only incomplete tasks
+			if at, _ := task["completed_at"].(time.Time); at.Before(completed) || completedSince == "now" {
+				continue
+			}
+		}
+		if modifiedSince != "" {
+			if at, _ := task["modified_at"].(time.Time); at.Before(modified) {
+				continue
+			}
+		}
+		result = append(result, task)
+	}
+	return result, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "now" {
+		return now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("Not a valid date or time: %s", value)
+}
+
+func (s *Server) listProjectTasks(c *call) {
+	project := s.lookup(c.param(0), "project")
+	if project == nil {
+		c.notFound("project", c.param(0))
+		return
+	}
+	tasks, err := filterTasks(s.projectTasks(project), c.query.Get("completed_since"), "")
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.list(s, tasks)
+}
+
+func (s *Server) listSectionTasks(c *call) {
+	if s.lookup(c.param(0), "section") == nil {
+		c.notFound("section", c.param(0))
+		return
+	}
+	tasks, err := filterTasks(s.objectsByID(s.sectionTasks(c.param(0))), c.query.Get("completed_since"), "")
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.list(s, tasks)
+}
+
+func (s *Server) listTagTasks(c *call) {
+	if s.lookup(c.param(0), "tag") == nil {
+		c.notFound("tag", c.param(0))
+		return
+	}
+	c.list(s, s.find("task", func(task object) bool {
+		return containsID(task.refs("tags"), c.param(0))
+	}))
+}
+
+func (s *Server) listSubtasks(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.objectsByID(task.ids("_subtasks")))
+	}
+}
+
+func (s *Server) createSubtask(c *call) {
+	parent := s.task(c)
+	if parent == nil {
+		return
+	}
+	task, err := s.newTask(c, parent)
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, task)
+}
+
+func (s *Server) setParent(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if !c.has("parent") {
+		c.badRequest("parent: Missing input")
+		return
+	}
+
+	id := task.str("gid")
+	var parent object
+	if parentID := c.str("parent"); parentID != "" {
+		if parent = s.lookup(parentID, "task"); parent == nil {
+			c.notFound("task", parentID)
+			return
+		}
+		for ancestor := parent; ancestor != nil; ancestor = s.objects[ancestor.ref("parent")] {
+			if ancestor.str("gid") == id {
+				c.badRequest("parent: A task cannot be a subtask of itself or its subtasks")
+				return
+			}
+		}
+	}
+
+	before, after := c.str("insert_before"), c.str("insert_after")
+	if parent != nil {
+		for _, other := range []string{before, after} {
+			if other != "" && !containsID(parent.ids("_subtasks"), other) {
+				c.badRequest("Task %s is not a subtask of %s", other, parent.str("gid"))
+				return
+			}
+		}
+	}
+
+	if old := s.objects[task.ref("parent")]; old != nil {
+		old["_subtasks"] = removeID(old.ids("_subtasks"), id)
+	}
+	task["parent"] = nil
+	if parent != nil {
+		subtasks := parent.ids("_subtasks")
+		if c.has("insert_after") && after == "" && len(subtasks) > 0 {
+			// A null insert_after inserts at the start
+			before = subtasks[0]
+		}
+		parent["_subtasks"] = insertID(subtasks, id, before, after)
+		task["parent"] = ref(parent.str("gid"))
+	}
+	task["modified_at"] = now()
+	c.ok(s, task)
+}
+
+func (s *Server) addProject(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+
+	projectID := c.str("project")
+	project := s.lookup(projectID, "project")
+	if project == nil {
+		c.notFound("project", projectID)
+		return
+	}
+
+	before, after, section := c.str("insert_before"), c.str("insert_after"), c.str("section")
+	if before != "" && after != "" {
+		c.badRequest("Only one of insert_before or insert_after may be specified")
+		return
+	}
+	for _, other := range []string{before, after} {
+		if other != "" && !containsID(project.ids("_tasks"), other) {
+			c.badRequest("Task %s is not in project %s", other, projectID)
+			return
+		}
+	}
+	if c.has("insert_after") && after == "" {
+		// A null insert_after inserts at the start of the project or section
+		tasks := project.ids("_tasks")
+		if section != "" {
+			tasks = s.sectionTasks(section)
+		}
+		for _, other := range tasks {
+			if other != task.str("gid") {
+				before = other
+				break
+			}
+		}
+	}
+
+	if err := s.addMembership(task, projectID, section, before, after); err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	task["modified_at"] = now()
+	c.empty()
+}
+
+func (s *Server) removeProject(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if s.lookup(c.str("project"), "project") == nil {
+		c.notFound("project", c.str("project"))
+		return
+	}
+	s.removeMembership(task, c.str("project"))
+	task["modified_at"] = now()
+	c.empty()
+}
+
+func (s *Server) listDependencies(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.objectsByID(task.refs("dependencies")))
+	}
+}
+
+func (s *Server) listDependents(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.objectsByID(task.refs("dependents")))
+	}
+}
+
+func (s *Server) addDependencies(c *call) {
+	s.linkTasks(c, "dependencies", "dependents", true)
+}
+
+func (s *Server) addDependents(c *call) {
+	s.linkTasks(c, "dependents", "dependencies", true)
+}
+
+func (s *Server) removeDependencies(c *call) {
+	s.linkTasks(c, "dependencies", "dependents", false)
+}
+
+func (s *Server) removeDependents(c *call) {
+	s.linkTasks(c, "dependents", "dependencies", false)
+}
+
+// linkTasks adds or removes dependency links in both directions
+func (s *Server) linkTasks(c *call, key, inverse string, add bool) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	id := task.str("gid")
+
+	ids := c.strings(key)
+	if len(ids) == 0 {
+		c.badRequest("%s: Missing input", key)
+		return
+	}
+	var others []object
+	for _, other := range ids {
+		obj := s.lookup(other, "task")
+		if obj == nil {
+			c.notFound("task", other)
+			return
+		}
+		if other == id {
+			c.badRequest("%s: A task cannot depend on itself", key)
+			return
+		}
+		others = append(others, obj)
+	}
+
+	for _, other := range others {
+		if add {
+			task.addRef(key, other.str("gid"))
+			other.addRef(inverse, id)
+		} else {
+			task.removeRef(key, other.str("gid"))
+			other.removeRef(inverse, id)
+		}
+	}
+	c.empty()
+}
+
+func (s *Server) listTaskTags(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.objectsByID(task.refs("tags")))
+	}
+}
+
+func (s *Server) addTag(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	tag := s.lookup(c.str("tag"), "tag")
+	if tag == nil {
+		c.notFound("tag", c.str("tag"))
+		return
+	}
+	task.addRef("tags", tag.str("gid"))
+	c.empty()
+}
+
+func (s *Server) removeTag(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if s.lookup(c.str("tag"), "tag") == nil {
+		c.notFound("tag", c.str("tag"))
+		return
+	}
+	task.removeRef("tags", c.str("tag"))
+	c.empty()
+}
+
+func (s *Server) addTaskFollowers(c *call) {
+	s.changeFollowers(c, true)
+}
+
+func (s *Server) removeTaskFollowers(c *call) {
+	s.changeFollowers(c, false)
+}
+
+func (s *Server) changeFollowers(c *call, add bool) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	followers, err := s.userRefs(c.strings("followers"))
+	if err != nil {
+		c.badRequest("followers: %v", err)
+		return
+	}
+	for _, follower := range followers {
+		if add {
+			task.addRef("followers", string(follower.(ref)))
+		} else {
+			task.removeRef("followers", string(follower.(ref)))
+		}
+	}
+	c.ok(s, task)
+}
+
+// searchTasks implements a subset of the search filters
+func (s *Server) searchTasks(c *call) {
+	workspace := c.param(0)
+	if s.lookup(workspace, "workspace") == nil {
+		c.notFound("workspace", workspace)
+		return
+	}
+
+	q := c.query
+	var filters []func(object) bool
+	add := func(f func(object) bool) {
+		filters = append(filters, f)
+	}
+
+	if text := strings.ToLower(q.Get("text")); text != "" {
+		add(func(task object) bool {
+			return strings.Contains(strings.ToLower(task.str("name")), text) ||
+				strings.Contains(strings.ToLower(task.str("notes")), text)
+		})
+	}
+	if subtype := q.Get("resource_subtype"); subtype != "" {
+		add(func(task object) bool { return task.str("resource_subtype") == subtype })
+	}
+	for _, key := range []string{"completed", "is_subtask", "has_attachment", "is_blocked", "is_blocking"} {
+		if value := q.Get(key); value != "" {
+			expected, err := strconv.ParseBool(value)
+			if err != nil {
+				c.badRequest("%s: Not a boolean: %s", key, value)
+				return
+			}
+			key := key
+			add(func(task object) bool { return s.taskFlag(task, key) == expected })
+		}
+	}
+
+	for _, key := range []string{"assignee", "projects", "sections", "tags", "followers", "created_by"} {
+		for _, op := range []string{"any", "not", "all"} {
+			value := q.Get(key + "." + op)
+			if value == "" {
+				continue
+			}
+			var ids []string
+			for _, id := range strings.Split(value, ",") {
+				if user := s.user(id); user != nil && (key == "assignee" || key == "followers") {
+					id = user.str("gid")
+				}
+				ids = append(ids, id)
+			}
+			key, op := key, op
+			add(func(task object) bool { return matchIDs(s.taskRefs(task, key), ids, op) })
+		}
+	}
+
+	for _, key := range []string{"created_at", "modified_at", "completed_at", "due_at"} {
+		for _, op := range []string{"before", "after"} {
+			value := q.Get(key + "." + op)
+			if value == "" {
+				continue
+			}
+			limit, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				c.badRequest("%s.%s: Not a valid time: %s", key, op, value)
+				return
+			}
+			key, op := key, op
+			add(func(task object) bool {
+				at, ok := task[key].(time.Time)
+				if !ok {
+					if s, _ := task[key].(string); s != "" {
+						at, _ = time.Parse(time.RFC3339Nano, s)
+						ok = true
+					}
+				}
+				return ok && (op == "before" && at.Before(limit) || op == "after" && at.After(limit))
+			})
+		}
+	}
+
+	for _, key := range []string{"due_on", "start_on"} {
+		for _, op := range []string{"", ".before", ".after"} {
+			value := q.Get(key + op)
+			if value == "" {
+				continue
+			}
+			key, op := key, op
+			add(func(task object) bool {
+				date := task.str(key)
+				switch op {
+				case ".before":
+					return date != "" && date < value
+				case ".after":
+					return date != "" && date > value
+				}
+				return date == value
+			})
+		}
+	}
+
+	for param, values := range q {
+		if !strings.HasPrefix(param, "custom_fields.") {
+			continue
+		}
+		parts := strings.Split(param, ".")
+		if len(parts) != 3 {
+			c.badRequest("%s: Invalid custom field filter", param)
+			return
+		}
+		id, predicate, value := parts[1], parts[2], values[0]
+		add(func(task object) bool {
+			return matchCustomField(task["_custom_fields"].(map[string]interface{})[id], predicate, value)
+		})
+	}
+
+	tasks := s.find("task", func(task object) bool {
+		if task.ref("workspace") != workspace {
+			return false
+		}
+		for _, f := range filters {
+			if !f(task) {
+				return false
+			}
+		}
+		return true
+	})
+
+	sortBy := q.Get("sort_by")
+	if sortBy == "" {
+		sortBy = "modified_at"
+	}
+	if sortBy == "due_date" {
+		sortBy = "due_on"
+	}
+	ascending := q.Get("sort_ascending") == "true"
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := sortKey(tasks[i], sortBy), sortKey(tasks[j], sortBy)
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	limit := 100
+	if value := q.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 100 {
+			c.badRequest("limit: Must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	// Search results are not paginated
+	data := []interface{}{}
+	for _, task := range tasks {
+		if len(c.fields) == 0 {
+			data = append(data, s.compact(task.str("gid")))
+		} else {
+			data = append(data, s.render(task, c.fields))
+		}
+	}
+	c.respond(200, data)
+}
+
+func sortKey(task object, key string) string {
+	switch v := task[key].(type) {
+	case time.Time:
+		return v.Format("2006-01-02T15:04:05.000000000Z07:00")
+	case string:
+		return v
+	}
+	return ""
+}
+
+// taskFlag evaluates a boolean search filter
+func (s *Server) taskFlag(task object, key string) bool {
+	switch key {
+	case "completed":
+		return task["completed"] == true
+	case "is_subtask":
+		return task.ref("parent") != ""
+	case "has_attachment":
+		return len(s.find("attachment", func(a object) bool { return a.ref("parent") == task.str("gid") })) > 0
+	case "is_blocked":
+		for _, dependency := range s.objectsByID(task.refs("dependencies")) {
+			if dependency["completed"] != true {
+				return true
+			}
+		}
+	case "is_blocking":
+		for _, dependent := range s.objectsByID(task.refs("dependents")) {
+			if dependent["completed"] != true {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// taskRefs returns the IDs of the objects a task refers to for a search filter
+func (s *Server) taskRefs(task object, key string) []string {
+	switch key {
+	case "assignee":
+		if assignee := task.ref("assignee"); assignee != "" {
+			return []string{assignee}
+		}
+		return []string{"null"}
+	case "projects", "sections":
+		var ids []string
+		for _, membership := range task.memberships() {
+			if r, ok := membership[strings.TrimSuffix(key, "s")].(ref); ok {
+				ids = append(ids, string(r))
+			}
+		}
+		return ids
+	case "created_by":
+		return nil
+	}
+	return task.refs(key)
+}
+
+func matchIDs(actual, expected []string, op string) bool {
+	count := 0
+	for _, id := range expected {
+		if containsID(actual, id) {
+			count++
+		}
+	}
+	switch op {
+	case "any":
+		return count > 0
+	case "not":
+		return count == 0
+	default:
+		return count == len(expected)
+	}
+}
+
+// matchCustomField evaluates a custom field search predicate
+func matchCustomField(stored interface{}, predicate, value string) bool {
+	switch predicate {
+	case "is_set":
+		return (stored != nil) == (value == "true")
+	case "value":
+		switch v := stored.(type) {
+		case float64:
+			n, err := strconv.ParseFloat(value, 64)
+			return err == nil && v == n
+		case bool:
+			return strconv.FormatBool(v) == value
+		case []interface{}:
+			for _, item := range v {
+				if item == value {
+					return true
+				}
+			}
+			return false
+		case map[string]interface{}:
+			return v["date"] == value
+		}
+		return fmt.Sprint(stored) == value
+	case "less_than", "greater_than":
+		v, ok := stored.(float64)
+		n, err := strconv.ParseFloat(value, 64)
+		if !ok || err != nil {
+			return false
+		}
+		if predicate == "less_than" {
+			return v < n
+		}
+		return v > n
+	case "contains", "starts_with", "ends_with":
+		text, _ := stored.(string)
+		text, value = strings.ToLower(text), strings.ToLower(value)
+		switch predicate {
+		case "contains":
+			return strings.Contains(text, value)
+		case "starts_with":
+			return strings.HasPrefix(text, value)
+		}
+		return strings.HasSuffix(text, value)
+	}
+	return false
+}
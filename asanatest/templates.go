@@ -0,0 +1,166 @@
+package asanatest
+
+import (
+	asana "bitbucket.org/mikehouston/asana-go"
+)
+
+// AddProjectTemplate creates a project template from an existing project.
+// Instantiating the template copies the project with all of its tasks. The
+// first requested date is the start date of the new project, and users
+// given for the requested roles become members of the new project.
+func (s *Server) AddProjectTemplate(projectID string, dates, roles []string) *asana.ProjectTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.lookup(projectID, "project")
+	if project == nil {
+		panic("asanatest: unknown project " + projectID)
+	}
+
+	requestedDates := []interface{}{}
+	for _, name := range dates {
+		date := s.create("date_variable", object{"name": name, "description": ""})
+		requestedDates = append(requestedDates, embed(date.str("gid")))
+	}
+	requestedRoles := []interface{}{}
+	for _, name := range roles {
+		role := s.create("template_role", object{"name": name})
+		requestedRoles = append(requestedRoles, embed(role.str("gid")))
+	}
+
+	template := s.create("project_template", object{
+		"name":             project["name"],
+		"description":      project["notes"],
+		"html_description": "",
+		"color":            project["color"],
+		"public":           false,
+		"owner":            ref(s.me),
+		"team":             project["team"],
+		"workspace":        project["workspace"],
+		"requested_dates":  requestedDates,
+		"requested_roles":  requestedRoles,
+		"_project":         projectID,
+	})
+
+	result := &asana.ProjectTemplate{}
+	s.decode(template, result)
+	return result
+}
+
+func (s *Server) listProjectTemplates(c *call) {
+	workspace, team := c.query.Get("workspace"), c.query.Get("team")
+	if workspace == "" && team == "" {
+		c.badRequest("workspace: One of workspace or team is required")
+		return
+	}
+	c.list(s, s.find("project_template", func(template object) bool {
+		return (workspace == "" || template.ref("workspace") == workspace) &&
+			(team == "" || template.ref("team") == team)
+	}))
+}
+
+func (s *Server) listTeamProjectTemplates(c *call) {
+	if s.lookup(c.param(0), "team") == nil {
+		c.notFound("team", c.param(0))
+		return
+	}
+	c.list(s, s.find("project_template", func(template object) bool {
+		return template.ref("team") == c.param(0)
+	}))
+}
+
+func (s *Server) projectTemplate(c *call) object {
+	template := s.lookup(c.param(0), "project_template")
+	if template == nil {
+		c.notFound("project_template", c.param(0))
+	}
+	return template
+}
+
+func (s *Server) getProjectTemplate(c *call) {
+	if template := s.projectTemplate(c); template != nil {
+		c.ok(s, template)
+	}
+}
+
+func (s *Server) instantiateProject(c *call) {
+	template := s.projectTemplate(c)
+	if template == nil {
+		return
+	}
+	source := s.lookup(template.str("_project"), "project")
+	if source == nil {
+		c.badRequest("project_template: The template's project has been deleted")
+		return
+	}
+	if c.str("name") == "" {
+		c.badRequest("name: Missing input")
+		return
+	}
+
+	// Requested values are given as lists of {gid, value}
+	values := func(key string) map[string]string {
+		result := make(map[string]string)
+		list, _ := c.data[key].([]interface{})
+		for _, item := range list {
+			if v, ok := item.(map[string]interface{}); ok {
+				result[object(v).str("gid")] = object(v).str("value")
+			}
+		}
+		return result
+	}
+	dates, roles := values("requested_dates"), values("requested_roles")
+	for _, key := range []string{"requested_dates", "requested_roles"} {
+		given := dates
+		if key == "requested_roles" {
+			given = roles
+		}
+		for id := range given {
+			if !containsID(template.refs(key), id) {
+				c.badRequest("%s: Unknown object: %s", key, id)
+				return
+			}
+		}
+		if c.data["is_strict"] == true && len(given) != len(template.refs(key)) {
+			c.badRequest("%s: Missing input", key)
+			return
+		}
+	}
+	var members []string
+	for _, id := range template.refs("requested_roles") {
+		if value := roles[id]; value != "" {
+			user := s.user(value)
+			if user == nil {
+				c.badRequest("requested_roles: Unknown user: %s", value)
+				return
+			}
+			members = append(members, user.str("gid"))
+		}
+	}
+	var startOn string
+	if ids := template.refs("requested_dates"); len(ids) > 0 {
+		startOn = dates[ids[0]]
+	}
+
+	job, err := s.startJob("instantiate_template", func(job object) error {
+		d := newDuplication([]string{"notes", "task_notes", "task_assignee", "task_subtasks",
+			"task_attachments", "task_dates", "task_dependencies", "task_tags"})
+		project, err := s.copyProject(source, c.str("name"), c.str("team"), d, startOn, "", false)
+		if err != nil {
+			return err
+		}
+		if public, ok := c.data["public"].(bool); ok {
+			project["public"] = public
+		}
+		for _, member := range members {
+			project.addRef("members", member)
+		}
+		job["new_project"] = ref(project.str("gid"))
+		return nil
+	})
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.created(s, job)
+}
@@ -0,0 +1,100 @@
+package asanatest
+
+import "time"
+
+// applyTimeTrackingEntry sets the duration and date of an entry from a
+// create or update request
+func (s *Server) applyTimeTrackingEntry(entry object, c *call) bool {
+	if c.has("duration_minutes") {
+		minutes, ok := c.data["duration_minutes"].(float64)
+		if !ok || minutes <= 0 || minutes != float64(int(minutes)) {
+			c.badRequest("duration_minutes: Must be a positive whole number of minutes")
+			return false
+		}
+		entry["duration_minutes"] = minutes
+	}
+	if c.has("entered_on") {
+		if _, err := time.Parse("2006-01-02", c.str("entered_on")); err != nil {
+			c.badRequest("entered_on: Not a valid date: %v", c.data["entered_on"])
+			return false
+		}
+		entry["entered_on"] = c.str("entered_on")
+	}
+	return true
+}
+
+// actualTime returns the total minutes logged on a task, or nil if no time
+// has been logged
+func (s *Server) actualTime(task object) interface{} {
+	entries := s.find("time_tracking_entry", func(entry object) bool {
+		return entry.ref("task") == task.str("gid")
+	})
+	if len(entries) == 0 {
+		return nil
+	}
+	total := 0.0
+	for _, entry := range entries {
+		total += entry["duration_minutes"].(float64)
+	}
+	return total
+}
+
+func (s *Server) listTimeTrackingEntries(c *call) {
+	if task := s.task(c); task != nil {
+		c.list(s, s.find("time_tracking_entry", func(entry object) bool {
+			return entry.ref("task") == task.str("gid")
+		}))
+	}
+}
+
+func (s *Server) createTimeTrackingEntry(c *call) {
+	task := s.task(c)
+	if task == nil {
+		return
+	}
+	if !c.has("duration_minutes") {
+		c.badRequest("duration_minutes: Missing input")
+		return
+	}
+
+	entry := object{
+		"entered_on": now().Format("2006-01-02"),
+		"created_by": ref(s.me),
+		"task":       ref(task.str("gid")),
+	}
+	if !s.applyTimeTrackingEntry(entry, c) {
+		return
+	}
+	c.created(s, s.create("time_tracking_entry", entry))
+}
+
+func (s *Server) timeTrackingEntry(c *call) object {
+	entry := s.lookup(c.param(0), "time_tracking_entry")
+	if entry == nil {
+		c.notFound("time_tracking_entry", c.param(0))
+	}
+	return entry
+}
+
+func (s *Server) getTimeTrackingEntry(c *call) {
+	if entry := s.timeTrackingEntry(c); entry != nil {
+		c.ok(s, entry)
+	}
+}
+
+func (s *Server) updateTimeTrackingEntry(c *call) {
+	entry := s.timeTrackingEntry(c)
+	if entry == nil {
+		return
+	}
+	if s.applyTimeTrackingEntry(entry, c) {
+		c.ok(s, entry)
+	}
+}
+
+func (s *Server) deleteTimeTrackingEntry(c *call) {
+	if entry := s.timeTrackingEntry(c); entry != nil {
+		s.remove(entry.str("gid"))
+		c.empty()
+	}
+}
@@ -0,0 +1,66 @@
+package asanatest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// typeaheadTypes are the resource types which can be searched with typeahead
+var typeaheadTypes = map[string]bool{
+	"user": true, "project": true, "tag": true, "task": true, "portfolio": true,
+}
+
+// typeahead lists the objects in a workspace whose names contain the query,
+// ignoring case. Names starting with the query are listed first.
+func (s *Server) typeahead(c *call) {
+	workspace := c.param(0)
+	if s.lookup(workspace, "workspace") == nil {
+		c.notFound("workspace", workspace)
+		return
+	}
+	resourceType := c.query.Get("resource_type")
+	if !typeaheadTypes[resourceType] {
+		c.badRequest("resource_type: Invalid value: %s", resourceType)
+		return
+	}
+	count := 20
+	if value := c.query.Get("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 100 {
+			c.badRequest("count: Must be between 1 and 100")
+			return
+		}
+		count = n
+	}
+
+	query := strings.ToLower(c.query.Get("query"))
+	matches := s.find(resourceType, func(obj object) bool {
+		if resourceType == "user" {
+			if !containsID(obj.refs("workspaces"), workspace) {
+				return false
+			}
+		} else if obj.ref("workspace") != workspace {
+			return false
+		}
+		return strings.Contains(strings.ToLower(obj.str("name")), query)
+	})
+	sort.SliceStable(matches, func(i, j int) bool {
+		return strings.HasPrefix(strings.ToLower(matches[i].str("name")), query) &&
+			!strings.HasPrefix(strings.ToLower(matches[j].str("name")), query)
+	})
+	if len(matches) > count {
+		matches = matches[:count]
+	}
+
+	result := []interface{}{}
+	for _, obj := range matches {
+		if len(c.fields) == 0 {
+			result = append(result, s.compact(obj.str("gid")))
+		} else {
+			result = append(result, s.render(obj, c.fields))
+		}
+	}
+	c.respond(http.StatusOK, result)
+}
@@ -0,0 +1,114 @@
+package asanatest
+
+import (
+	"sort"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+)
+
+// AddUserTaskListSection adds a section to the end of a user's task list in
+// a workspace. Tasks can then be moved into it by updating their
+// assignee_section.
+func (s *Server) AddUserTaskListSection(userID, workspaceID, name string) *asana.Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := s.taskListFor(s.user(userID).str("gid"), workspaceID)
+	section := s.create("section", object{
+		"name":    name,
+		"project": ref(list.str("gid")),
+	})
+	list["_sections"] = append(list.ids("_sections"), section.str("gid"))
+
+	result := &asana.Section{}
+	s.decode(section, result)
+	return result
+}
+
+// taskListFor returns the task list of a user in a workspace, creating it
+// with a single "Recently assigned" section the first time it is needed
+func (s *Server) taskListFor(userID, workspaceID string) object {
+	lists := s.find("user_task_list", func(list object) bool {
+		return list.ref("owner") == userID && list.ref("workspace") == workspaceID
+	})
+	if len(lists) > 0 {
+		return lists[0]
+	}
+
+	list := s.create("user_task_list", object{
+		"name":      "My Tasks",
+		"owner":     ref(userID),
+		"workspace": ref(workspaceID),
+	})
+	section := s.create("section", object{
+		"name":    "Recently assigned",
+		"project": ref(list.str("gid")),
+	})
+	list["_sections"] = []string{section.str("gid")}
+	return list
+}
+
+// userTaskListTasks returns the tasks assigned to the owner of a task list,
+// ordered by section
+func (s *Server) userTaskListTasks(list object) []object {
+	tasks := s.find("task", func(task object) bool {
+		return task.ref("assignee") == list.ref("owner") && task.ref("workspace") == list.ref("workspace")
+	})
+	position := make(map[string]int)
+	for i, id := range list.ids("_sections") {
+		position[id] = i
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return position[tasks[i].ref("assignee_section")] < position[tasks[j].ref("assignee_section")]
+	})
+	return tasks
+}
+
+func (s *Server) getUserTaskListForUser(c *call) {
+	user := s.user(c.param(0))
+	if user == nil {
+		c.notFound("user", c.param(0))
+		return
+	}
+	workspace := c.query.Get("workspace")
+	if workspace == "" {
+		c.badRequest("workspace: Missing input")
+		return
+	}
+	if s.lookup(workspace, "workspace") == nil {
+		c.notFound("workspace", workspace)
+		return
+	}
+	if !containsID(user.refs("workspaces"), workspace) {
+		c.badRequest("user: User %s is not a member of workspace %s", user.str("gid"), workspace)
+		return
+	}
+	c.ok(s, s.taskListFor(user.str("gid"), workspace))
+}
+
+func (s *Server) userTaskList(c *call) object {
+	list := s.lookup(c.param(0), "user_task_list")
+	if list == nil {
+		c.notFound("user_task_list", c.param(0))
+	}
+	return list
+}
+
+func (s *Server) getUserTaskList(c *call) {
+	if list := s.userTaskList(c); list != nil {
+		c.ok(s, list)
+	}
+}
+
+func (s *Server) listUserTaskListTasks(c *call) {
+	list := s.userTaskList(c)
+	if list == nil {
+		return
+	}
+	tasks, err := filterTasks(s.userTaskListTasks(list), c.query.Get("completed_since"), "")
+	if err != nil {
+		c.badRequest("%v", err)
+		return
+	}
+	c.list(s, tasks)
+}
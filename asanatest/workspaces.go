@@ -0,0 +1,223 @@
+package asanatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+)
+
+// AddWorkspace creates a workspace which the authenticated user is a member of
+func (s *Server) AddWorkspace(name string) *asana.Workspace {
+	return s.addWorkspace(name, false)
+}
+
+// AddOrganization creates an organization which the authenticated user is a
+// member of. Projects in organizations must belong to a team.
+func (s *Server) AddOrganization(name string) *asana.Workspace {
+	return s.addWorkspace(name, true)
+}
+
+func (s *Server) addWorkspace(name string, organization bool) *asana.Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workspace := s.create("workspace", object{
+		"name":            name,
+		"is_organization": organization,
+		"email_domains":   []interface{}{"example.com"},
+	})
+	s.objects[s.me].addRef("workspaces", workspace.str("gid"))
+
+	result := &asana.Workspace{}
+	s.decode(workspace, result)
+	return result
+}
+
+// AddUser creates a user who is a member of the given workspace
+func (s *Server) AddUser(workspaceID, name, email string) *asana.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.create("user", object{
+		"name":       name,
+		"email":      email,
+		"workspaces": []interface{}{ref(workspaceID)},
+	})
+
+	result := &asana.User{}
+	s.decode(user, result)
+	return result
+}
+
+// AddTeam creates a team in an organization, with the authenticated user as
+// a member
+func (s *Server) AddTeam(organizationID, name string) *asana.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team := s.create("team", object{
+		"name":         name,
+		"description":  "",
+		"organization": ref(organizationID),
+	})
+
+	result := &asana.Team{}
+	s.decode(team, result)
+	return result
+}
+
+// AddFavorite marks a project as a favorite of the authenticated user
+func (s *Server) AddFavorite(projectID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	me := s.objects[s.me]
+	me["_favorites"] = append(me.ids("_favorites"), projectID)
+}
+
+// Me returns the authenticated user
+func (s *Server) Me() *asana.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &asana.User{}
+	s.decode(s.objects[s.me], result)
+	return result
+}
+
+// Decode loads the current state of any object into v, which should be a
+// pointer to the corresponding asana type. This allows tests to inspect the
+// server state without making API requests.
+func (s *Server) Decode(id string, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.objects[id]
+	if obj == nil {
+		return fmt.Errorf("unknown object %s", id)
+	}
+	return s.decode(obj, v)
+}
+
+func (s *Server) decode(obj object, v interface{}) error {
+	b, err := json.Marshal(s.render(obj, nil))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// user finds a user by ID or email, or "me" for the authenticated user
+func (s *Server) user(id string) object {
+	if id == "me" {
+		return s.objects[s.me]
+	}
+	if user := s.lookup(id, "user"); user != nil {
+		return user
+	}
+	for _, user := range s.find("user", nil) {
+		if strings.EqualFold(user.str("email"), id) {
+			return user
+		}
+	}
+	return nil
+}
+
+// userRefs resolves a list of user identifiers
+func (s *Server) userRefs(ids []string) ([]interface{}, error) {
+	result := []interface{}{}
+	for _, id := range ids {
+		user := s.user(id)
+		if user == nil {
+			return nil, fmt.Errorf("user: Unknown object: %s", id)
+		}
+		result = append(result, ref(user.str("gid")))
+	}
+	return result, nil
+}
+
+func (s *Server) listWorkspaces(c *call) {
+	me := s.objects[s.me]
+	c.list(s, s.objectsByID(me.refs("workspaces")))
+}
+
+func (s *Server) getWorkspace(c *call) {
+	workspace := s.lookup(c.param(0), "workspace")
+	if workspace == nil {
+		c.notFound("workspace", c.param(0))
+		return
+	}
+	c.ok(s, workspace)
+}
+
+func (s *Server) listUsers(c *call) {
+	workspace := c.query.Get("workspace")
+	if workspace == "" {
+		c.badRequest("workspace: Missing input")
+		return
+	}
+	if s.lookup(workspace, "workspace") == nil {
+		c.notFound("workspace", workspace)
+		return
+	}
+	c.list(s, s.find("user", func(user object) bool {
+		return containsID(user.refs("workspaces"), workspace)
+	}))
+}
+
+func (s *Server) getUser(c *call) {
+	user := s.user(c.param(0))
+	if user == nil {
+		c.notFound("user", c.param(0))
+		return
+	}
+	c.ok(s, user)
+}
+
+func (s *Server) listFavorites(c *call) {
+	user := s.user(c.param(0))
+	if user == nil {
+		c.notFound("user", c.param(0))
+		return
+	}
+	if user.str("gid") != s.me {
+		c.forbidden("You can only view your own favorites")
+		return
+	}
+	resourceType := c.query.Get("resource_type")
+	workspace := c.query.Get("workspace")
+	if resourceType == "" || workspace == "" {
+		c.badRequest("resource_type and workspace are required")
+		return
+	}
+
+	var favorites []object
+	for _, obj := range s.objectsByID(user.ids("_favorites")) {
+		if obj["resource_type"] == resourceType && obj.ref("workspace") == workspace {
+			favorites = append(favorites, obj)
+		}
+	}
+	c.list(s, favorites)
+}
+
+func (s *Server) listTeams(c *call) {
+	organization := s.lookup(c.param(0), "workspace")
+	if organization == nil {
+		c.notFound("organization", c.param(0))
+		return
+	}
+	c.list(s, s.find("team", func(team object) bool {
+		return team.ref("organization") == c.param(0)
+	}))
+}
+
+func (s *Server) getTeam(c *call) {
+	team := s.lookup(c.param(0), "team")
+	if team == nil {
+		c.notFound("team", c.param(0))
+		return
+	}
+	c.ok(s, team)
+}
@@ -1,10 +1,14 @@
 package asana
 
 import (
+	"context"
 	"fmt"
-	"github.com/pkg/errors"
 	"io"
+	"net/http"
+	"net/url"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // Attachment represents any file attached to a task in Asana,
@@ -63,28 +67,233 @@ func (a *Attachment) GetID() string {
 	return a.ID
 }
 
+// Fetch loads the full details for this Attachment, including a fresh
+// DownloadURL
+func (a *Attachment) Fetch(client *Client, opts ...*Options) error {
+	return a.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (a *Attachment) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading attachment details for %q", a.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/attachments/%s", a.ID), nil, a, opts...)
+	return err
+}
+
+// Delete removes this attachment from its task
+func (a *Attachment) Delete(client *Client) error {
+	return a.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (a *Attachment) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting attachment %q", a.ID)
+
+	return client.delete(ctx, fmt.Sprintf("/attachments/%s", a.ID))
+}
+
+// ProgressFunc is called as a file is transferred with the number of bytes
+// transferred so far and the total size, which is -1 if it is not known
+type ProgressFunc func(transferred, total int64)
+
+// DownloadUnsupportedError is returned by Download for attachments whose
+// content cannot be downloaded through Asana, such as files hosted by box
+// or links to external sites
+type DownloadUnsupportedError struct {
+	ID   string
+	Host string
+}
+
+func (e *DownloadUnsupportedError) Error() string {
+	return fmt.Sprintf("Attachment %s hosted by %q cannot be downloaded", e.ID, e.Host)
+}
+
+// Download writes the content of this attachment to w and returns the
+// number of bytes written. If progress is not nil it is called after each
+// part of the file is written.
+//
+// The details of the attachment are loaded first if DownloadURL is not
+// set, as in the compact records returned by Attachments. Download URLs
+// expire an hour after they are issued, so the URL is refreshed once if the
+// download is refused. Attachments hosted by box have no download URL, and
+// a *DownloadUnsupportedError is returned for them without downloading
+// anything.
+func (a *Attachment) Download(client *Client, w io.Writer, progress ProgressFunc) (int64, error) {
+	return a.DownloadContext(context.Background(), client, w, progress)
+}
+
+// DownloadContext is like Download but uses ctx for the requests
+func (a *Attachment) DownloadContext(ctx context.Context, client *Client, w io.Writer, progress ProgressFunc) (int64, error) {
+	client.trace("Downloading attachment %q", a.ID)
+
+	refreshed := false
+	if a.DownloadURL == "" && a.Host != "box" {
+		if err := a.FetchContext(ctx, client); err != nil {
+			return 0, err
+		}
+		refreshed = true
+	}
+
+	for {
+		if a.DownloadURL == "" || a.Host == "box" {
+			return 0, &DownloadUnsupportedError{ID: a.ID, Host: a.Host}
+		}
+
+		resp, err := client.download(ctx, a.DownloadURL)
+		if err != nil {
+			return 0, errors.Wrap(err, "Download attachment")
+		}
+		if resp.StatusCode == http.StatusOK {
+			defer resp.Body.Close()
+			total := resp.ContentLength
+			if total < 0 && a.Size != nil {
+				total = int64(*a.Size)
+			}
+			return io.Copy(w, &progressReader{Reader: resp.Body, total: total, progress: progress})
+		}
+		resp.Body.Close()
+
+		expired := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized
+		if !expired || refreshed {
+			return 0, errors.Errorf("Download attachment %s: %s", a.ID, resp.Status)
+		}
+		client.trace("Download URL for attachment %q has expired", a.ID)
+		if err := a.FetchContext(ctx, client); err != nil {
+			return 0, err
+		}
+		refreshed = true
+	}
+}
+
+// download makes a GET request for attachment content. The API credentials
+// are only sent if the content is served by the API host.
+func (c *Client) download(ctx context.Context, link string) (*http.Response, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := c.DownloadClient
+	if u.Host == c.BaseURL.Host {
+		httpClient = c.HTTPClient
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(request)
+}
+
+// progressReader reports the bytes read from a file. Seeking is passed
+// through to the file if it supports it, so uploads can be retried.
+type progressReader struct {
+	io.Reader
+	start       int64
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 && r.progress != nil {
+		r.transferred += int64(n)
+		r.progress(r.transferred, r.total)
+	}
+	return n, err
+}
+
+func (r *progressReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := r.Reader.(io.Seeker)
+	if !ok {
+		return 0, errors.New("File does not support seeking")
+	}
+	position, err := seeker.Seek(offset, whence)
+	if err == nil {
+		r.transferred = position - r.start
+	}
+	return position, err
+}
+
+func (r *progressReader) Close() error {
+	if closer, ok := r.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // Attachments lists all attachments attached to a task
 func (t *Task) Attachments(client *Client, opts ...*Options) ([]*Attachment, *NextPage, error) {
+	return t.AttachmentsContext(context.Background(), client, opts...)
+}
+
+// AttachmentsContext is like Attachments but uses ctx for the API request
+func (t *Task) AttachmentsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Attachment, *NextPage, error) {
 	client.trace("Listing attachments for %q", t.Name)
 
 	var result []*Attachment
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/tasks/%s/attachments", t.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/attachments", t.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateAttachments returns an Iterator over the attachments on this task
+func (t *Task) IterateAttachments(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Attachment] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Attachment, *NextPage, error) {
+		return t.AttachmentsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 type NewAttachment struct {
 	Reader      io.ReadCloser
 	FileName    string
 	ContentType string
+
+	// The size of the file in bytes, reported as the total to Progress. If
+	// zero the size is found by seeking to the end of Reader, if possible.
+	Size int64
+
+	// Progress, if not nil, is called as the file is uploaded. If the upload
+	// is retried the count starts again from zero.
+	Progress ProgressFunc
+}
+
+// reader returns the file content, wrapped to report progress if requested
+func (a *NewAttachment) reader() io.ReadCloser {
+	if a.Progress == nil {
+		return a.Reader
+	}
+	r := &progressReader{Reader: a.Reader, total: -1, progress: a.Progress}
+	if a.Size > 0 {
+		r.total = a.Size
+	}
+	if seeker, ok := a.Reader.(io.Seeker); ok {
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			r.start = start
+			if end, err := seeker.Seek(0, io.SeekEnd); err == nil && r.total < 0 {
+				r.total = end - start
+			}
+			seeker.Seek(start, io.SeekStart)
+		}
+	}
+	return r
 }
 
 func (t *Task) CreateAttachment(client *Client, request *NewAttachment) (*Attachment, error) {
+	return t.CreateAttachmentContext(context.Background(), client, request)
+}
+
+// CreateAttachmentContext is like CreateAttachment but uses ctx for the upload
+func (t *Task) CreateAttachmentContext(ctx context.Context, client *Client, request *NewAttachment) (*Attachment, error) {
 	client.trace("Uploading attachment for %q", t.Name)
 
 	result := &Attachment{}
-	err := client.postMultipart(fmt.Sprintf("/tasks/%s/attachments", t.ID), result, "file", request.Reader, request.FileName, request.ContentType)
+	err := client.postMultipart(ctx, fmt.Sprintf("/tasks/%s/attachments", t.ID), result, "file", request.reader(), request.FileName, request.ContentType)
 	if err != nil {
 		return nil, errors.Wrap(err, "Upload attachment")
 	}
@@ -99,11 +308,16 @@ type ExternalAttachmentRequest struct {
 }
 
 func (t *Task) CreateExternalAttachment(client *Client, request *ExternalAttachmentRequest) (*Attachment, error) {
+	return t.CreateExternalAttachmentContext(context.Background(), client, request)
+}
+
+// CreateExternalAttachmentContext is like CreateExternalAttachment but uses ctx for the API request
+func (t *Task) CreateExternalAttachmentContext(ctx context.Context, client *Client, request *ExternalAttachmentRequest) (*Attachment, error) {
 	client.trace("Creating external attachment for %q", t.Name)
 	request.ResourceSubtype = "external"
 
 	result := &Attachment{}
-	err := client.post(fmt.Sprintf("/tasks/%s/attachments", t.ID), request, result)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/attachments", t.ID), request, result)
 	if err != nil {
 		return nil, errors.Wrap(err, "Create external attachment")
 	}
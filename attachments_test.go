@@ -0,0 +1,122 @@
+package asana_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestAttachmentDownload(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	task, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase:  asana.TaskBase{Name: "Task"},
+		Workspace: workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := strings.Repeat("file content ", 1000)
+	var uploaded []int64
+	attachment, err := task.CreateAttachment(client, &asana.NewAttachment{
+		Reader:      io.NopCloser(strings.NewReader(content)),
+		FileName:    "file.txt",
+		ContentType: "text/plain",
+		Size:        int64(len(content)),
+		Progress: func(transferred, total int64) {
+			if total != int64(len(content)) {
+				t.Errorf("Expected upload total %d, but saw %d", len(content), total)
+			}
+			uploaded = append(uploaded, transferred)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploaded) == 0 || uploaded[len(uploaded)-1] != int64(len(content)) {
+		t.Errorf("Expected upload progress to reach %d, but saw %v", len(content), uploaded)
+	}
+
+	// Compact records are fetched to find the download URL
+	attachments, err := task.IterateAttachments(context.Background(), client).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments) != 1 || attachments[0].DownloadURL != "" {
+		t.Fatalf("Expected a compact attachment, but saw %+v", attachments)
+	}
+	buffer := &bytes.Buffer{}
+	var downloaded int64
+	n, err := attachments[0].Download(client, buffer, func(transferred, total int64) {
+		if total != int64(len(content)) {
+			t.Errorf("Expected download total %d, but saw %d", len(content), total)
+		}
+		downloaded = transferred
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(content)) || buffer.String() != content {
+		t.Errorf("Downloaded %d bytes, expected %d", n, len(content))
+	}
+	if downloaded != n {
+		t.Errorf("Expected download progress to reach %d, but saw %d", n, downloaded)
+	}
+
+	// Expired download URLs are refreshed
+	server.ExpireDownloadURLs()
+	requests := len(server.Requests())
+	buffer.Reset()
+	if _, err := attachment.Download(client, buffer, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != content {
+		t.Error("Unexpected content after refreshing the download URL")
+	}
+	if n := len(server.Requests()) - requests; n != 3 {
+		t.Errorf("Expected 3 requests, but saw %d", n)
+	}
+
+	if err := attachment.Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	err = (&asana.Attachment{ID: attachment.ID}).Fetch(client)
+	if e, ok := asana.IsAsanaError(err); !ok || e.StatusCode != 404 {
+		t.Errorf("Expected a 404 error fetching a deleted attachment, but saw %v", err)
+	}
+}
+
+func TestAttachmentDownloadUnsupported(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	task, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase:  asana.TaskBase{Name: "Task"},
+		Workspace: workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	external, err := task.CreateExternalAttachment(client, &asana.ExternalAttachmentRequest{
+		Name: "Design",
+		URL:  "https://example.com/design",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attachment := range []*asana.Attachment{
+		{ID: external.ID},
+		{ID: "1", Host: "box", ViewURL: "https://app.box.com/file/1"},
+	} {
+		_, err := attachment.Download(client, io.Discard, nil)
+		if _, ok := err.(*asana.DownloadUnsupportedError); !ok {
+			t.Errorf("Expected an unsupported download, but saw %v", err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package asana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxBatchActions is the largest number of actions the API accepts in a
+// single batch request
+const MaxBatchActions = 10
+
+type batchAction struct {
+	RelativePath string      `json:"relative_path"`
+	Method       string      `json:"method"`
+	Data         interface{} `json:"data,omitempty"`
+	Options      *Options    `json:"options,omitempty"`
+}
+
+type batchRequest struct {
+	Actions []*batchAction `json:"actions"`
+}
+
+type batchResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers"`
+	Body       *Response         `json:"body"`
+}
+
+// BatchResult holds the outcome of a single action in a Batch. It is
+// populated when the batch is executed.
+type BatchResult struct {
+	// The HTTP status code returned for the action
+	StatusCode int
+
+	// The HTTP headers returned for the action
+	Headers map[string]string
+
+	// The undecoded data returned for the action
+	Data json.RawMessage
+
+	// The error returned for the action, or nil if it succeeded
+	Error *Error
+}
+
+// Err returns the error for the action, or nil if it succeeded
+func (r *BatchResult) Err() error {
+	if r.Error == nil {
+		return nil
+	}
+	return r.Error
+}
+
+// Decode unmarshals the data returned for the action into v. If the action
+// failed its error is returned instead.
+func (r *BatchResult) Decode(v interface{}) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if r.Data == nil {
+		return errors.New("Missing data from batch result")
+	}
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return errors.Wrap(err, "Unable to parse batch result data")
+	}
+	return nil
+}
+
+// Batch combines several API actions into as few HTTP requests as possible,
+// using the batch endpoint. Each request may contain at most MaxBatchActions
+// actions; larger batches are split across multiple requests.
+//
+// Actions are executed in the order they were added, but the API does not
+// guarantee that one action in a request completes before the next begins,
+// so actions which depend on each other should be executed in separate
+// batches.
+//
+// Each method which adds an action returns the BatchResult for that action,
+// which is filled in by Execute. The zero value is an empty batch ready to
+// use.
+type Batch struct {
+	actions []*batchAction
+	results []*BatchResult
+}
+
+// NewBatch creates an empty Batch
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Len returns the number of actions in the batch
+func (b *Batch) Len() int {
+	return len(b.actions)
+}
+
+// Add queues an arbitrary action. The path is relative to the API base URL
+// and data is encoded as the body of the action.
+func (b *Batch) Add(method, path string, data interface{}, opts ...*Options) *BatchResult {
+	action := &batchAction{
+		RelativePath: path,
+		Method:       strings.ToLower(method),
+		Data:         data,
+	}
+	if len(opts) > 0 {
+		action.Options = opts[0]
+	}
+
+	result := &BatchResult{}
+	b.actions = append(b.actions, action)
+	b.results = append(b.results, result)
+	return result
+}
+
+// Get queues a request for a single resource, such as "/tasks/1234"
+func (b *Batch) Get(path string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodGet, path, nil, opts...)
+}
+
+// CreateTask queues the creation of a new task
+func (b *Batch) CreateTask(request *CreateTaskRequest, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, "/tasks", request, opts...)
+}
+
+// UpdateTask queues an update to an existing task
+func (b *Batch) UpdateTask(taskID string, request *UpdateTaskRequest, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPut, fmt.Sprintf("/tasks/%s", taskID), request, opts...)
+}
+
+// DeleteTask queues the deletion of a task
+func (b *Batch) DeleteTask(taskID string) *BatchResult {
+	return b.Add(http.MethodDelete, fmt.Sprintf("/tasks/%s", taskID), nil)
+}
+
+// AddProject queues adding a task to a project
+func (b *Batch) AddProject(taskID string, request *AddProjectRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/addProject", taskID), request.encode())
+}
+
+// RemoveProject queues removing a task from a project
+func (b *Batch) RemoveProject(taskID, projectID string) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/removeProject", taskID), map[string]interface{}{
+		"project": projectID,
+	})
+}
+
+// AddTag queues adding a tag to a task
+func (b *Batch) AddTag(taskID, tagID string) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/addTag", taskID), map[string]interface{}{
+		"tag": tagID,
+	})
+}
+
+// RemoveTag queues removing a tag from a task
+func (b *Batch) RemoveTag(taskID, tagID string) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/removeTag", taskID), map[string]interface{}{
+		"tag": tagID,
+	})
+}
+
+// SetParent queues changing the parent of a task
+func (b *Batch) SetParent(taskID string, request *SetParentRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/setParent", taskID), request.encode())
+}
+
+// AddDependencies queues marking tasks as dependencies of a task
+func (b *Batch) AddDependencies(taskID string, request *AddDependenciesRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/addDependencies", taskID), request)
+}
+
+// AddDependents queues marking tasks as dependents of a task
+func (b *Batch) AddDependents(taskID string, request *AddDependentsRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/addDependents", taskID), request)
+}
+
+// RemoveDependencies queues unlinking dependencies from a task
+func (b *Batch) RemoveDependencies(taskID string, request *RemoveDependenciesRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/removeDependencies", taskID), request)
+}
+
+// RemoveDependents queues unlinking dependents from a task
+func (b *Batch) RemoveDependents(taskID string, request *RemoveDependentsRequest) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/removeDependents", taskID), request)
+}
+
+// CreateComment queues adding a comment story to a task
+func (b *Batch) CreateComment(taskID string, story *StoryBase, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/stories", taskID), story, opts...)
+}
+
+// CreateProject queues the creation of a new project
+func (b *Batch) CreateProject(request *CreateProjectRequest, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, "/projects", request, opts...)
+}
+
+// UpdateProject queues an update to an existing project
+func (b *Batch) UpdateProject(projectID string, request *UpdateProjectRequest, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPut, fmt.Sprintf("/projects/%s", projectID), request, opts...)
+}
+
+// Execute sends the queued actions to the API and returns their results, in
+// the order the actions were added. An error is only returned if a batch
+// request itself fails; the outcome of each action is reported by its
+// BatchResult.
+func (b *Batch) Execute(client *Client) ([]*BatchResult, error) {
+	return b.ExecuteContext(context.Background(), client)
+}
+
+// ExecuteContext is like Execute but uses ctx for the API requests
+func (b *Batch) ExecuteContext(ctx context.Context, client *Client) ([]*BatchResult, error) {
+	// Validate all actions before sending any of them
+	for i, action := range b.actions {
+		if validator, ok := action.Data.(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				return nil, errors.Wrapf(err, "Invalid batch action %d: %s %s", i, action.Method, action.RelativePath)
+			}
+		}
+	}
+
+	for start := 0; start < len(b.actions); start += MaxBatchActions {
+		end := start + MaxBatchActions
+		if end > len(b.actions) {
+			end = len(b.actions)
+		}
+
+		client.info("Executing batch actions %d to %d of %d", start+1, end, len(b.actions))
+
+		var responses []*batchResponse
+		request := &batchRequest{Actions: b.actions[start:end]}
+		if err := client.post(ctx, "/batch", request, &responses); err != nil {
+			return b.results, err
+		}
+		if len(responses) != end-start {
+			return b.results, errors.Errorf("Expected %d batch results, but received %d", end-start, len(responses))
+		}
+
+		for i, response := range responses {
+			response.decode(b.results[start+i])
+		}
+	}
+
+	return b.results, nil
+}
+
+func (r *batchResponse) decode(result *BatchResult) {
+	result.StatusCode = r.StatusCode
+	result.Headers = r.Headers
+	result.Data = nil
+	result.Error = nil
+
+	if r.Body != nil {
+		result.Data = r.Body.Data
+	}
+	if r.StatusCode/100 == 2 {
+		return
+	}
+
+	status := fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))
+	if r.Body != nil && len(r.Body.Errors) > 0 {
+		result.Error = r.Body.Errors[0].withType(r.StatusCode, status)
+	} else {
+		result.Error = &Error{
+			StatusCode: r.StatusCode,
+			Type:       status,
+			Message:    "Unknown error",
+		}
+	}
+	for key, value := range r.Headers {
+		if strings.EqualFold(key, "Retry-After") {
+			result.Error.RetryAfter = parseRetryAfter(value)
+		}
+	}
+}
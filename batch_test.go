@@ -0,0 +1,90 @@
+package asana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestBatchChunking(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/batch" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+
+		var request struct {
+			Data batchRequest `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(request.Data.Actions))
+
+		var responses []interface{}
+		for _, action := range request.Data.Actions {
+			if action.RelativePath == "/tasks/missing" {
+				responses = append(responses, map[string]interface{}{
+					"status_code": 404,
+					"headers":     map[string]string{},
+					"body": map[string]interface{}{
+						"errors": []map[string]string{{"message": "task: Unknown object: missing"}},
+					},
+				})
+				continue
+			}
+			data := action.Data.(map[string]interface{})
+			responses = append(responses, map[string]interface{}{
+				"status_code": 201,
+				"body": map[string]interface{}{
+					"data": map[string]interface{}{"gid": data["name"], "name": data["name"]},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": responses})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	batch := NewBatch()
+	for i := 0; i < 11; i++ {
+		batch.CreateTask(&CreateTaskRequest{TaskBase: TaskBase{Name: fmt.Sprint(i)}})
+	}
+	missing := batch.UpdateTask("missing", &UpdateTaskRequest{})
+
+	results, err := batch.Execute(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sizes) != 2 || sizes[0] != 10 || sizes[1] != 2 {
+		t.Errorf("Expected batches of 10 and 2 actions, but saw %v", sizes)
+	}
+	if len(results) != 12 {
+		t.Fatalf("Expected 12 results, but saw %d", len(results))
+	}
+
+	for i, result := range results[:11] {
+		task := &Task{}
+		if err := result.Decode(task); err != nil {
+			t.Fatal(err)
+		}
+		if task.Name != fmt.Sprint(i) {
+			t.Errorf("Expected task %d to be named %q, but saw %q", i, fmt.Sprint(i), task.Name)
+		}
+	}
+
+	if missing != results[11] {
+		t.Error("Expected the queued result to be returned")
+	}
+	if !IsNotFoundError(missing.Err()) {
+		t.Errorf("Expected a not found error, but saw %v", missing.Err())
+	}
+	if err := missing.Decode(&Task{}); err == nil {
+		t.Error("Expected Decode to return the action error")
+	}
+}
@@ -17,9 +17,9 @@ import (
 var options struct {
 	Token string `long:"token" description:"Personal Access Token used to authorize access to the API" env:"ASANA_TOKEN" required:"true"`
 
-	Workspace []string `long:"workspace" short:"w" description:"Workspace to access"`
-	Project   []string `long:"project" short:"p" description:"Project to access"`
-	Task      []string `long:"task" short:"t" description:"Task to access"`
+	Workspace []string `long:"workspace" short:"w" description:"Workspace to access, by ID or name"`
+	Project   []string `long:"project" short:"p" description:"Project to access, by ID, name or link. Names are looked up in the first workspace"`
+	Task      []string `long:"task" short:"t" description:"Task to access, by ID, name or Asana link. Names are looked up in the first workspace"`
 
 	Attach     string `long:"attach" description:"Attach a file to a task"`
 	AddSection string `long:"add-section" description:"Add a new section to a project"`
@@ -54,12 +54,25 @@ func main() {
 		},
 	})
 	if options.Debug {
-		client.Debug = true
-		client.DefaultOptions.Pretty = true
+		client.DefaultOptions.Debug = asana.Bool(true)
+		client.DefaultOptions.Pretty = asana.Bool(true)
 	}
 	client.Verbose = options.Verbose
 	client.DefaultOptions.Enable = []asana.Feature{asana.StringIDs, asana.NewSections, asana.NewTaskSubtypes}
 
+	// Resolve workspace names. Project and task names are looked up in the
+	// first workspace.
+	var workspaces []*asana.Workspace
+	workspaceID := ""
+	for _, w := range options.Workspace {
+		workspace, err := client.ResolveWorkspace(w)
+		check(err)
+		workspaces = append(workspaces, workspace)
+	}
+	if len(workspaces) > 0 {
+		workspaceID = workspaces[0].ID
+	}
+
 	// Load a task object
 	if options.Task == nil {
 
@@ -72,15 +85,16 @@ func main() {
 				return
 			}
 
-			for _, w := range options.Workspace {
-				workspace := &asana.Workspace{ID: w}
+			for _, workspace := range workspaces {
 				check(ListProjects(client, workspace))
 			}
 			return
 		}
 
 		for _, p := range options.Project {
-			project := &asana.Project{ID: p}
+			resource, err := client.Resolve(workspaceID, "project", p)
+			check(err)
+			project := resource.Project()
 
 			if options.AddSection != "" {
 				request := &asana.SectionBase{
@@ -98,7 +112,14 @@ func main() {
 	}
 
 	for _, t := range options.Task {
-		task := &asana.Task{ID: t}
+		var task *asana.Task
+		if link, err := asana.ParseWebURL(t); err == nil && link.Task != nil {
+			task = link.Task
+		} else {
+			resource, err := client.Resolve(workspaceID, "task", t)
+			check(err)
+			task = resource.Task()
+		}
 		check(task.Fetch(client))
 
 		fmt.Printf("Task %s: %q\n", task.ID, task.Name)
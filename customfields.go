@@ -1,6 +1,7 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -63,6 +64,10 @@ const (
 	Percentage = "percentage"
 	Custom     = "custom"
 	None       = "none"
+
+	// Duration is the format of time tracking fields such as estimated
+	// time. The values of these number fields are in minutes.
+	Duration = "duration"
 )
 
 type CustomFieldBase struct {
@@ -148,8 +153,19 @@ type AddCustomFieldSettingRequest struct {
 }
 
 func (p *Project) AddCustomFieldSetting(client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
+	return p.AddCustomFieldSettingContext(context.Background(), client, request)
+}
+
+// AddCustomFieldSettingContext is like AddCustomFieldSetting but uses ctx for the API request
+func (p *Project) AddCustomFieldSettingContext(ctx context.Context, client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
 	client.trace("Attach custom field %q to project %q", request.CustomField, p.ID)
 
+	result := &CustomFieldSetting{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), request.encode(), result)
+	return result, err
+}
+
+func (request *AddCustomFieldSettingRequest) encode() map[string]interface{} {
 	// Custom request encoding
 	m := map[string]interface{}{}
 	m["custom_field"] = request.CustomField
@@ -167,12 +183,15 @@ func (p *Project) AddCustomFieldSetting(client *Client, request *AddCustomFieldS
 		m["insert_before"] = request.InsertBefore
 	}
 
-	result := &CustomFieldSetting{}
-	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), m, result)
-	return result, err
+	return m
 }
 
 func (p *Project) RemoveCustomFieldSetting(client *Client, customFieldID string) error {
+	return p.RemoveCustomFieldSettingContext(context.Background(), client, customFieldID)
+}
+
+// RemoveCustomFieldSettingContext is like RemoveCustomFieldSetting but uses ctx for the API request
+func (p *Project) RemoveCustomFieldSettingContext(ctx context.Context, client *Client, customFieldID string) error {
 	client.trace("Remove custom field %q from project %q", customFieldID, p.ID)
 
 	// Custom request encoding
@@ -180,7 +199,7 @@ func (p *Project) RemoveCustomFieldSetting(client *Client, customFieldID string)
 		"custom_field": customFieldID,
 	}
 
-	err := client.post(fmt.Sprintf("/projects/%s/removeCustomFieldSetting", p.ID), m, nil)
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/removeCustomFieldSetting", p.ID), m, nil)
 	return err
 }
 
@@ -200,7 +219,12 @@ type AddProjectLocalCustomFieldRequest struct {
 }
 
 func (p *Project) AddProjectLocalCustomField(client *Client, request *AddProjectLocalCustomFieldRequest) (*CustomFieldSetting, error) {
-	client.trace("Attach custom field %q to project %q", request.CustomField, p.ID)
+	return p.AddProjectLocalCustomFieldContext(context.Background(), client, request)
+}
+
+// AddProjectLocalCustomFieldContext is like AddProjectLocalCustomField but uses ctx for the API request
+func (p *Project) AddProjectLocalCustomFieldContext(ctx context.Context, client *Client, request *AddProjectLocalCustomFieldRequest) (*CustomFieldSetting, error) {
+	client.trace("Attach custom field %q to project %q", request.CustomField.Name, p.ID)
 
 	// Custom request encoding
 	m := map[string]interface{}{}
@@ -220,7 +244,7 @@ func (p *Project) AddProjectLocalCustomField(client *Client, request *AddProject
 	}
 
 	result := &CustomFieldSetting{}
-	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), m, result)
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), m, result)
 	return result, err
 }
 
@@ -236,10 +260,15 @@ type CreateCustomFieldRequest struct {
 }
 
 func (c *Client) CreateCustomField(request *CreateCustomFieldRequest) (*CustomField, error) {
+	return c.CreateCustomFieldContext(context.Background(), request)
+}
+
+// CreateCustomFieldContext is like CreateCustomField but uses ctx for the API request
+func (c *Client) CreateCustomFieldContext(ctx context.Context, request *CreateCustomFieldRequest) (*CustomField, error) {
 	c.trace("Create custom field %q in workspace %s", request.Name, request.Workspace)
 
 	result := &CustomField{}
-	err := c.post("/custom_fields", request, result)
+	err := c.post(ctx, "/custom_fields", request, result)
 	return result, err
 }
 
@@ -287,43 +316,47 @@ type CustomFieldValue struct {
 
 // Fetch loads the full details for this CustomField
 func (f *CustomField) Fetch(client *Client, options ...*Options) error {
+	return f.FetchContext(context.Background(), client, options...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (f *CustomField) FetchContext(ctx context.Context, client *Client, options ...*Options) error {
 	client.trace("Loading details for custom field %q", f.ID)
 
-	_, err := client.get(fmt.Sprintf("/custom_fields/%s", f.ID), nil, f, options...)
+	_, err := client.get(ctx, fmt.Sprintf("/custom_fields/%s", f.ID), nil, f, options...)
 	return err
 }
 
 // CustomFields returns the compact records for all custom fields in the workspace
 func (w *Workspace) CustomFields(client *Client, options ...*Options) ([]*CustomField, *NextPage, error) {
+	return w.CustomFieldsContext(context.Background(), client, options...)
+}
+
+// CustomFieldsContext is like CustomFields but uses ctx for the API request
+func (w *Workspace) CustomFieldsContext(ctx context.Context, client *Client, options ...*Options) ([]*CustomField, *NextPage, error) {
 	client.trace("Listing custom fields in workspace %s...\n", w.ID)
 	var result []*CustomField
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/workspaces/%s/custom_fields", w.ID), nil, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/workspaces/%s/custom_fields", w.ID), nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateCustomFields returns an Iterator over the custom fields in this workspace
+func (w *Workspace) IterateCustomFields(ctx context.Context, client *Client, opts ...*Options) *Iterator[*CustomField] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*CustomField, *NextPage, error) {
+		return w.CustomFieldsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllCustomFields repeatedly pages through all available custom fields in a workspace
 func (w *Workspace) AllCustomFields(client *Client, options ...*Options) ([]*CustomField, error) {
-	var allCustomFields []*CustomField
-	nextPage := &NextPage{}
-
-	var customFields []*CustomField
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		customFields, nextPage, err = w.CustomFields(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return w.AllCustomFieldsContext(context.Background(), client, options...)
+}
 
-		allCustomFields = append(allCustomFields, customFields...)
-	}
-	return allCustomFields, nil
+// AllCustomFieldsContext is like AllCustomFields but uses ctx for the API requests
+func (w *Workspace) AllCustomFieldsContext(ctx context.Context, client *Client, options ...*Options) ([]*CustomField, error) {
+	it := w.IterateCustomFields(ctx, client, options...)
+	it.PageSize = 50
+	return it.All()
 }
@@ -3,6 +3,7 @@ package asana
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestCustomFieldBase_Precision_ParseZero(t *testing.T) {
@@ -47,3 +48,141 @@ func TestCustomFieldBase_Precision_SerializeZero(t *testing.T) {
 	}
 
 }
+
+func testTask(t *testing.T) *Task {
+	task := &Task{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "1",
+	"custom_fields": [
+		{"gid": "10", "name": "Estimate", "resource_subtype": "number", "precision": 1, "number_value": 2.5},
+		{"gid": "11", "name": "Priority", "resource_subtype": "enum", "enum_options": [
+			{"gid": "111", "name": "High", "enabled": true},
+			{"gid": "112", "name": "Low", "enabled": false}
+		], "enum_value": {"gid": "111", "name": "High", "enabled": true}},
+		{"gid": "12", "name": "Launch", "resource_subtype": "date", "date_value": {"date": "2020-03-01"}},
+		{"gid": "13", "name": "Reviewers", "resource_subtype": "people", "people_value": [{"gid": "99"}]},
+		{"gid": "14", "name": "Done", "resource_subtype": "percent", "format": "percentage", "precision": 0}
+	]
+}
+`), task); err != nil {
+		t.Fatal(err)
+	}
+	return task
+}
+
+func TestTask_CustomFieldGetters(t *testing.T) {
+	task := testTask(t)
+
+	if n, err := task.CustomFieldNumber("Estimate"); err != nil || n == nil || *n != 2.5 {
+		t.Errorf("Expected estimate 2.5, but saw %v, %v", n, err)
+	}
+	if option, err := task.CustomFieldEnum("11"); err != nil || option == nil || option.Name != "High" {
+		t.Errorf("Expected priority High, but saw %v, %v", option, err)
+	}
+	if date, err := task.CustomFieldDate("Launch"); err != nil || date == nil || date.Date == nil {
+		t.Errorf("Expected a launch date, but saw %v, %v", date, err)
+	}
+	if people, err := task.CustomFieldPeople("Reviewers"); err != nil || len(people) != 1 || people[0].ID != "99" {
+		t.Errorf("Expected one reviewer, but saw %v, %v", people, err)
+	}
+
+	if _, err := task.CustomFieldText("Estimate"); err == nil {
+		t.Error("Expected reading a number field as text to fail")
+	}
+	if _, err := task.CustomFieldText("Missing"); err == nil {
+		t.Error("Expected reading a missing field to fail")
+	}
+}
+
+func TestCustomFieldUpdate(t *testing.T) {
+	task := testTask(t)
+
+	launch := Date(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	values, err := task.UpdateCustomFields().
+		Number("Estimate", 3.5).
+		Enum("Priority", "high").
+		Date("Launch", launch).
+		People("Reviewers", "98", "99").
+		Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"10":3.5,"11":"111","12":{"date":"2021-06-01"},"13":["98","99"]}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, but saw %s", expected, encoded)
+	}
+
+	for name, update := range map[string]*CustomFieldUpdate{
+		"too precise":      task.UpdateCustomFields().Number("Estimate", 3.25),
+		"disabled option":  task.UpdateCustomFields().Enum("Priority", "Low"),
+		"unknown option":   task.UpdateCustomFields().Enum("Priority", "Urgent"),
+		"wrong type":       task.UpdateCustomFields().Text("Launch", "tomorrow"),
+		"unknown field":    task.UpdateCustomFields().Text("Notes", "text"),
+		"unsupported type": task.UpdateCustomFields().Set("Done", 0.5),
+	} {
+		if _, err := update.Values(); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
+
+func TestCustomField_PercentagePrecision(t *testing.T) {
+	precision := 0
+	field := &CustomField{CustomFieldBase: CustomFieldBase{
+		ResourceSubtype: FieldTypeNumber,
+		Format:          Percentage,
+		Precision:       &precision,
+	}}
+	if _, err := field.EncodeValue(0.25); err != nil {
+		t.Errorf("Expected 25%% to be valid: %v", err)
+	}
+	if _, err := field.EncodeValue(0.251); err == nil {
+		t.Error("Expected 25.1% to be rejected")
+	}
+}
+
+func TestCustomField_Duration(t *testing.T) {
+	task := &Task{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "1",
+	"custom_fields": [
+		{"gid": "10", "name": "Estimated time", "resource_subtype": "number", "format": "duration", "precision": 0, "number_value": 90},
+		{"gid": "11", "name": "Points", "resource_subtype": "number", "precision": 0, "number_value": 3}
+	]
+}
+`), task); err != nil {
+		t.Fatal(err)
+	}
+
+	estimate, err := task.CustomFieldDuration("Estimated time")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate == nil || *estimate != 90*time.Minute {
+		t.Errorf("Expected 1h30m, but saw %v", estimate)
+	}
+	if _, err := task.CustomFieldDuration("Points"); err == nil {
+		t.Error("Expected an error for a number field which is not a duration")
+	}
+
+	values, err := task.UpdateCustomFields().Duration("Estimated time", 2*time.Hour).Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["10"] != 120.0 {
+		t.Errorf("Expected 120 minutes, but saw %v", values["10"])
+	}
+	if _, err := task.UpdateCustomFields().Duration("Estimated time", 90*time.Second).Values(); err == nil {
+		t.Error("Expected an error for a fraction of a minute")
+	}
+	if _, err := task.UpdateCustomFields().Duration("Points", time.Hour).Values(); err == nil {
+		t.Error("Expected an error for a number field which is not a duration")
+	}
+}
@@ -0,0 +1,454 @@
+package asana
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CustomField returns the value of the custom field on this task with the
+// given ID or name, or nil if the task does not have the field. IDs are
+// matched before names.
+//
+// The task must have been fetched with its custom_fields for this to
+// succeed.
+func (t *Task) CustomField(key string) *CustomFieldValue {
+	for _, value := range t.CustomFields {
+		if value.ID == key {
+			return value
+		}
+	}
+	for _, value := range t.CustomFields {
+		if value.Name == key {
+			return value
+		}
+	}
+	return nil
+}
+
+// customField finds a custom field and checks it has the expected type
+func (t *Task) customField(key string, fieldType FieldType) (*CustomFieldValue, error) {
+	value := t.CustomField(key)
+	if value == nil {
+		return nil, errors.Errorf("Task %s has no custom field %q", t.ID, key)
+	}
+	if value.ResourceSubtype != fieldType {
+		return nil, errors.Errorf("Custom field %q is a %s field, not %s", key, value.ResourceSubtype, fieldType)
+	}
+	return value, nil
+}
+
+// CustomFieldText returns the value of a text custom field, or nil if it is
+// not set
+func (t *Task) CustomFieldText(key string) (*string, error) {
+	value, err := t.customField(key, FieldTypeText)
+	if err != nil {
+		return nil, err
+	}
+	return value.TextValue, nil
+}
+
+// CustomFieldNumber returns the value of a number custom field, or nil if it
+// is not set
+func (t *Task) CustomFieldNumber(key string) (*float64, error) {
+	value, err := t.customField(key, FieldTypeNumber)
+	if err != nil {
+		return nil, err
+	}
+	return value.NumberValue, nil
+}
+
+// CustomFieldDuration returns the value of a time tracking field, such as
+// estimated time, or nil if it is not set
+func (t *Task) CustomFieldDuration(key string) (*time.Duration, error) {
+	value, err := t.customField(key, FieldTypeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if value.Format != Duration {
+		return nil, errors.Errorf("Custom field %q is not a time tracking field", key)
+	}
+	if value.NumberValue == nil {
+		return nil, nil
+	}
+	d := time.Duration(*value.NumberValue * float64(time.Minute))
+	return &d, nil
+}
+
+// CustomFieldBool returns the value of a boolean custom field, or nil if it
+// is not set
+func (t *Task) CustomFieldBool(key string) (*bool, error) {
+	value, err := t.customField(key, FieldTypeBoolean)
+	if err != nil {
+		return nil, err
+	}
+	return value.BooleanValue, nil
+}
+
+// CustomFieldDate returns the value of a date custom field, or nil if it is
+// not set. Only one of Date and DateTime is set, depending on whether the
+// value includes a time.
+func (t *Task) CustomFieldDate(key string) (*DateValue, error) {
+	value, err := t.customField(key, FieldTypeDate)
+	if err != nil {
+		return nil, err
+	}
+	return value.DateValue, nil
+}
+
+// CustomFieldEnum returns the selected option of an enum custom field, or
+// nil if none is selected
+func (t *Task) CustomFieldEnum(key string) (*EnumValue, error) {
+	value, err := t.customField(key, FieldTypeEnum)
+	if err != nil {
+		return nil, err
+	}
+	return value.EnumValue, nil
+}
+
+// CustomFieldMultiEnum returns the selected options of a multi_enum custom
+// field
+func (t *Task) CustomFieldMultiEnum(key string) ([]*EnumValue, error) {
+	value, err := t.customField(key, FieldTypeMultiEnum)
+	if err != nil {
+		return nil, err
+	}
+	return value.MultiEnumValues, nil
+}
+
+// CustomFieldPeople returns the users selected in a people custom field
+func (t *Task) CustomFieldPeople(key string) ([]*User, error) {
+	value, err := t.customField(key, FieldTypePeople)
+	if err != nil {
+		return nil, err
+	}
+	return value.PeopleValue, nil
+}
+
+// EnumOption returns the enum option with the given ID or name, ignoring
+// case when matching names
+func (f *CustomField) EnumOption(key string) *EnumValue {
+	for _, option := range f.EnumOptions {
+		if option.ID == key {
+			return option
+		}
+	}
+	for _, option := range f.EnumOptions {
+		if strings.EqualFold(option.Name, key) {
+			return option
+		}
+	}
+	return nil
+}
+
+// EncodeValue validates a value for this custom field and converts it to
+// the representation expected in the custom_fields of a create or update
+// request. A nil value clears the field.
+//
+// The accepted values depend on the ResourceSubtype of the field:
+//
+//	text        string
+//	number      any integer or floating point type, with no more decimal places than the Precision
+//	            or a time.Duration for time tracking fields, which is stored in minutes
+//	boolean     bool
+//	enum        the ID or name of an enabled option, or an *EnumValue
+//	multi_enum  a []string of option IDs or names, or an []*EnumValue
+//	date        a Date or *Date for a date, or a time.Time or *time.Time for a date and time
+//	people      a []string of user IDs, or a []*User
+//
+// Enum options are resolved using the field's EnumOptions.
+func (f *CustomField) EncodeValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch f.ResourceSubtype {
+	case FieldTypeText:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+
+	case FieldTypeNumber:
+		if d, ok := value.(time.Duration); ok && f.Format == Duration {
+			value = d.Minutes()
+		}
+		if n, ok := toFloat(value); ok {
+			if err := f.checkPrecision(n); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+
+	case FieldTypeBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+
+	case FieldTypeEnum:
+		switch v := value.(type) {
+		case string:
+			return f.enumOptionID(v)
+		case *EnumValue:
+			return f.enumOptionID(v.ID)
+		}
+
+	case FieldTypeMultiEnum:
+		var keys []string
+		switch v := value.(type) {
+		case []string:
+			keys = v
+		case []*EnumValue:
+			for _, option := range v {
+				keys = append(keys, option.ID)
+			}
+		default:
+			return nil, f.typeError(value)
+		}
+
+		ids := []string{}
+		for _, key := range keys {
+			id, err := f.enumOptionID(key)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+
+	case FieldTypeDate:
+		switch v := value.(type) {
+		case Date:
+			return &DateValue{Date: &v}, nil
+		case *Date:
+			return &DateValue{Date: v}, nil
+		case time.Time:
+			return &DateValue{DateTime: &v}, nil
+		case *time.Time:
+			return &DateValue{DateTime: v}, nil
+		case *DateValue:
+			return v, nil
+		}
+
+	case FieldTypePeople:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []*User:
+			ids := []string{}
+			for _, user := range v {
+				ids = append(ids, user.ID)
+			}
+			return ids, nil
+		}
+
+	default:
+		return nil, errors.Errorf("Custom field %q has unsupported type %q", f.Name, f.ResourceSubtype)
+	}
+
+	return nil, f.typeError(value)
+}
+
+func (f *CustomField) typeError(value interface{}) error {
+	return errors.Errorf("Invalid value of type %T for %s custom field %q", value, f.ResourceSubtype, f.Name)
+}
+
+func (f *CustomField) enumOptionID(key string) (string, error) {
+	option := f.EnumOption(key)
+	if option == nil {
+		return "", errors.Errorf("Custom field %q has no option %q", f.Name, key)
+	}
+	if !option.Enabled {
+		return "", errors.Errorf("Option %q of custom field %q is disabled", option.Name, f.Name)
+	}
+	return option.ID, nil
+}
+
+// checkPrecision rejects numbers with more decimal places than the field
+// displays. Percentages are displayed multiplied by 100, so 0.25 has a
+// precision of 0.
+func (f *CustomField) checkPrecision(n float64) error {
+	if f.Precision == nil {
+		return nil
+	}
+	scaled := n * math.Pow10(*f.Precision)
+	if f.Format == Percentage {
+		scaled *= 100
+	}
+	if math.Abs(scaled-math.Round(scaled)) > 1e-9*math.Max(1, math.Abs(scaled)) {
+		return errors.Errorf("Value %v has more than %d decimal places for custom field %q", n, *f.Precision, f.Name)
+	}
+	return nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// CustomFieldUpdate builds the custom_fields of a task create or update
+// request, resolving fields by ID or name and validating each value against
+// the field definition. Errors are deferred until Values is called, so that
+// calls can be chained:
+//
+//	values, err := task.UpdateCustomFields().
+//		Enum("Priority", "High").
+//		Number("Estimate", 2.5).
+//		Clear("Notes").
+//		Values()
+//	if err != nil {
+//		...
+//	}
+//	err = task.Update(client, &asana.UpdateTaskRequest{CustomFields: values})
+type CustomFieldUpdate struct {
+	fields []*CustomField
+	values map[string]interface{}
+	err    error
+}
+
+// NewCustomFieldUpdate creates a CustomFieldUpdate for the given field
+// definitions, such as those of a project's custom field settings
+func NewCustomFieldUpdate(fields ...*CustomField) *CustomFieldUpdate {
+	return &CustomFieldUpdate{
+		fields: fields,
+		values: make(map[string]interface{}),
+	}
+}
+
+// UpdateCustomFields creates a CustomFieldUpdate for the custom fields of
+// this task. The task must have been fetched with its custom_fields,
+// including the enum_options of enum fields.
+func (t *Task) UpdateCustomFields() *CustomFieldUpdate {
+	var fields []*CustomField
+	for _, value := range t.CustomFields {
+		if value.Enabled != nil && !*value.Enabled {
+			continue
+		}
+		fields = append(fields, &value.CustomField)
+	}
+	return NewCustomFieldUpdate(fields...)
+}
+
+func (u *CustomFieldUpdate) field(key string) *CustomField {
+	for _, field := range u.fields {
+		if field.ID == key {
+			return field
+		}
+	}
+	for _, field := range u.fields {
+		if field.Name == key {
+			return field
+		}
+	}
+	return nil
+}
+
+// Set sets the value of a field. See CustomField.EncodeValue for the values
+// accepted for each type of field.
+func (u *CustomFieldUpdate) Set(key string, value interface{}) *CustomFieldUpdate {
+	if u.err != nil {
+		return u
+	}
+
+	field := u.field(key)
+	if field == nil {
+		u.err = errors.Errorf("Unknown custom field %q", key)
+		return u
+	}
+
+	encoded, err := field.EncodeValue(value)
+	if err != nil {
+		u.err = err
+		return u
+	}
+	u.values[field.ID] = encoded
+	return u
+}
+
+// Clear removes the value of a field
+func (u *CustomFieldUpdate) Clear(key string) *CustomFieldUpdate {
+	return u.Set(key, nil)
+}
+
+// Text sets the value of a text field
+func (u *CustomFieldUpdate) Text(key, value string) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// Number sets the value of a number field
+func (u *CustomFieldUpdate) Number(key string, value float64) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// Duration sets the value of a time tracking field, such as estimated time
+func (u *CustomFieldUpdate) Duration(key string, value time.Duration) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// Bool sets the value of a boolean field
+func (u *CustomFieldUpdate) Bool(key string, value bool) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// Date sets the value of a date field to a date without a time
+func (u *CustomFieldUpdate) Date(key string, value Date) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// DateTime sets the value of a date field to a date and time
+func (u *CustomFieldUpdate) DateTime(key string, value time.Time) *CustomFieldUpdate {
+	return u.Set(key, value)
+}
+
+// Enum selects an option of an enum field by ID or name
+func (u *CustomFieldUpdate) Enum(key, option string) *CustomFieldUpdate {
+	return u.Set(key, option)
+}
+
+// MultiEnum selects options of a multi_enum field by ID or name, replacing
+// any existing selection
+func (u *CustomFieldUpdate) MultiEnum(key string, options ...string) *CustomFieldUpdate {
+	return u.Set(key, options)
+}
+
+// People sets the users selected in a people field, replacing any existing
+// selection
+func (u *CustomFieldUpdate) People(key string, userIDs ...string) *CustomFieldUpdate {
+	return u.Set(key, userIDs)
+}
+
+// Values returns the encoded values keyed by custom field ID, for use as the
+// CustomFields of a CreateTaskRequest or UpdateTaskRequest, or the first
+// error encountered
+func (u *CustomFieldUpdate) Values() (map[string]interface{}, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	return u.values, nil
+}
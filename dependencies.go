@@ -0,0 +1,331 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The task fields loaded when crawling dependencies
+var dependencyFields = []string{
+	"name", "completed", "start_on", "due_on", "dependencies", "dependents",
+}
+
+// DependencyGraph is an in-memory graph of tasks and the dependencies between
+// them. An edge from a task to one of its dependencies means the dependency
+// must be finished before the task can start.
+//
+// Tasks are kept in the order they were added, which is used to break ties
+// so that the results of each method are deterministic.
+type DependencyGraph struct {
+	tasks        map[string]*Task
+	order        []string
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// DependencyCycleError is returned when an operation requires the graph to be
+// acyclic, but the dependencies form a cycle
+type DependencyCycleError struct {
+	// The tasks in the cycle, each depending on the one after it. The last
+	// task depends on the first.
+	Tasks []*Task
+}
+
+func (e *DependencyCycleError) Error() string {
+	var names []string
+	for _, task := range e.Tasks {
+		names = append(names, fmt.Sprintf("%q", task.Name))
+	}
+	if len(names) > 0 {
+		names = append(names, names[0])
+	}
+	return fmt.Sprintf("Dependency cycle: %s", strings.Join(names, " -> "))
+}
+
+// NewDependencyGraph creates an empty graph
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		tasks:        make(map[string]*Task),
+		dependencies: make(map[string][]string),
+		dependents:   make(map[string][]string),
+	}
+}
+
+// AddTask adds a task to the graph, along with the edges to the tasks in its
+// Dependencies and Dependents. If the task is already in the graph it is
+// replaced with the new value.
+func (g *DependencyGraph) AddTask(task *Task) {
+	g.add(task)
+	for _, dependency := range task.Dependencies {
+		g.AddDependency(task.ID, dependency.ID)
+	}
+	for _, dependent := range task.Dependents {
+		g.AddDependency(dependent.ID, task.ID)
+	}
+}
+
+func (g *DependencyGraph) add(task *Task) {
+	if _, ok := g.tasks[task.ID]; !ok {
+		g.order = append(g.order, task.ID)
+	}
+	g.tasks[task.ID] = task
+}
+
+// AddDependency records that the task with ID taskID depends on the task with
+// ID dependencyID. Tasks which are not yet in the graph are added with only
+// their IDs.
+func (g *DependencyGraph) AddDependency(taskID, dependencyID string) {
+	for _, id := range []string{taskID, dependencyID} {
+		if _, ok := g.tasks[id]; !ok {
+			g.add(&Task{ID: id})
+		}
+	}
+	for _, id := range g.dependencies[taskID] {
+		if id == dependencyID {
+			return
+		}
+	}
+	g.dependencies[taskID] = append(g.dependencies[taskID], dependencyID)
+	g.dependents[dependencyID] = append(g.dependents[dependencyID], taskID)
+}
+
+// Task returns the task with the given ID, or nil if it is not in the graph
+func (g *DependencyGraph) Task(id string) *Task {
+	return g.tasks[id]
+}
+
+// Tasks returns all tasks in the graph, in the order they were added
+func (g *DependencyGraph) Tasks() []*Task {
+	return g.lookup(g.order)
+}
+
+// Dependencies returns the tasks which the given task depends on
+func (g *DependencyGraph) Dependencies(id string) []*Task {
+	return g.lookup(g.dependencies[id])
+}
+
+// Dependents returns the tasks which depend on the given task
+func (g *DependencyGraph) Dependents(id string) []*Task {
+	return g.lookup(g.dependents[id])
+}
+
+func (g *DependencyGraph) lookup(ids []string) []*Task {
+	result := make([]*Task, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, g.tasks[id])
+	}
+	return result
+}
+
+// Cycle returns the tasks in a dependency cycle, or nil if the graph is
+// acyclic. Each task in the cycle depends on the one after it, and the last
+// depends on the first.
+func (g *DependencyGraph) Cycle() []*Task {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dependency := range g.dependencies[id] {
+			switch state[dependency] {
+			case visiting:
+				// The cycle is the part of the stack from the dependency
+				for i, other := range stack {
+					if other == dependency {
+						return append([]string(nil), stack[i:]...)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, id := range g.order {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return g.lookup(cycle)
+			}
+		}
+	}
+	return nil
+}
+
+// TopologicalOrder returns the tasks ordered so that every task comes after
+// all of its dependencies. A *DependencyCycleError is returned if there is a
+// cycle.
+func (g *DependencyGraph) TopologicalOrder() ([]*Task, error) {
+	remaining := make(map[string]int)
+	for _, id := range g.order {
+		remaining[id] = len(g.dependencies[id])
+	}
+
+	// Kahn's algorithm, taking ready tasks in the order they were added
+	var result []*Task
+	done := make(map[string]bool)
+	for len(result) < len(g.order) {
+		progress := false
+		for _, id := range g.order {
+			if done[id] || remaining[id] > 0 {
+				continue
+			}
+			done[id] = true
+			progress = true
+			result = append(result, g.tasks[id])
+			for _, dependent := range g.dependents[id] {
+				remaining[dependent]--
+			}
+		}
+		if !progress {
+			return nil, &DependencyCycleError{Tasks: g.Cycle()}
+		}
+	}
+	return result, nil
+}
+
+// TaskDuration returns the number of days of work remaining for a task when
+// computing the critical path. This is the number of days from StartOn to
+// DueOn inclusive, or one day if the task has only a due date. Completed
+// tasks and tasks without dates take no time.
+func TaskDuration(task *Task) int {
+	if IsTrue(task.Completed) {
+		return 0
+	}
+	if task.DueOn == nil {
+		return 0
+	}
+	if task.StartOn == nil {
+		return 1
+	}
+
+	days := int(time.Time(*task.DueOn).Sub(time.Time(*task.StartOn)).Hours()/24) + 1
+	if days < 1 {
+		return 1
+	}
+	return days
+}
+
+// CriticalPath returns the longest chain of dependent tasks, measured by the
+// sum of their durations as given by TaskDuration, and the total number of
+// days. The chain starts with a task which has no dependencies and each
+// following task depends on the one before it. A *DependencyCycleError is
+// returned if there is a cycle.
+func (g *DependencyGraph) CriticalPath() ([]*Task, int, error) {
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The longest path ending at each task, following the topological order
+	finish := make(map[string]int)
+	previous := make(map[string]string)
+	var last string
+	for _, task := range order {
+		start := 0
+		for _, dependency := range g.dependencies[task.ID] {
+			if finish[dependency] > start || previous[task.ID] == "" && finish[dependency] == start {
+				start = finish[dependency]
+				previous[task.ID] = dependency
+			}
+		}
+		finish[task.ID] = start + TaskDuration(task)
+		if last == "" || finish[task.ID] > finish[last] {
+			last = task.ID
+		}
+	}
+	if last == "" {
+		return nil, 0, nil
+	}
+
+	var path []*Task
+	for id := last; id != ""; id = previous[id] {
+		path = append([]*Task{g.tasks[id]}, path...)
+	}
+	return path, finish[last], nil
+}
+
+// CrawlDependencies loads the tasks reachable from the given tasks by
+// following their dependencies and dependents, and returns the resulting
+// graph. Each task is fetched once.
+func CrawlDependencies(ctx context.Context, client *Client, tasks ...*Task) (*DependencyGraph, error) {
+	g := NewDependencyGraph()
+	for _, task := range tasks {
+		g.add(&Task{ID: task.ID})
+	}
+	return g, g.crawl(ctx, client, make(map[string]bool))
+}
+
+// crawl fetches each task in the graph which has not been loaded, adding
+// the tasks it links to, until every task has been loaded
+func (g *DependencyGraph) crawl(ctx context.Context, client *Client, loaded map[string]bool) error {
+	for {
+		var pending []string
+		for _, id := range g.order {
+			if !loaded[id] {
+				pending = append(pending, id)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		for _, id := range pending {
+			task := &Task{ID: id}
+			if err := task.FetchContext(ctx, client, &Options{Fields: dependencyFields}); err != nil {
+				return err
+			}
+			g.AddTask(task)
+			loaded[id] = true
+		}
+	}
+}
+
+// DependencyGraph crawls the dependencies and dependents of this task
+func (t *Task) DependencyGraph(client *Client) (*DependencyGraph, error) {
+	return t.DependencyGraphContext(context.Background(), client)
+}
+
+// DependencyGraphContext is like DependencyGraph but uses ctx for the API requests
+func (t *Task) DependencyGraphContext(ctx context.Context, client *Client) (*DependencyGraph, error) {
+	client.trace("Crawling dependencies of task %q", t.ID)
+
+	return CrawlDependencies(ctx, client, &Task{ID: t.ID})
+}
+
+// DependencyGraph loads the tasks in this project and crawls their
+// dependencies and dependents, including tasks in other projects
+func (p *Project) DependencyGraph(client *Client) (*DependencyGraph, error) {
+	return p.DependencyGraphContext(context.Background(), client)
+}
+
+// DependencyGraphContext is like DependencyGraph but uses ctx for the API requests
+func (p *Project) DependencyGraphContext(ctx context.Context, client *Client) (*DependencyGraph, error) {
+	client.trace("Crawling dependencies of project %q", p.ID)
+
+	tasks, err := p.IterateTasks(ctx, client, &Options{Fields: dependencyFields}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewDependencyGraph()
+	loaded := make(map[string]bool)
+	for _, task := range tasks {
+		g.AddTask(task)
+		loaded[task.ID] = true
+	}
+	return g, g.crawl(ctx, client, loaded)
+}
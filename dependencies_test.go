@@ -0,0 +1,123 @@
+package asana_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func date(s string) *asana.Date {
+	t, _ := time.Parse("2006-01-02", s)
+	d := asana.Date(t)
+	return &d
+}
+
+func names(tasks []*asana.Task) []string {
+	var result []string
+	for _, task := range tasks {
+		result = append(result, task.Name)
+	}
+	return result
+}
+
+func TestDependencyGraph(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Release"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Design and build run in parallel before release. Docs are in another
+	// project, so are only found by crawling.
+	tasks := make(map[string]*asana.Task)
+	create := func(name, start, due string, projects ...string) {
+		request := &asana.CreateTaskRequest{
+			TaskBase:  asana.TaskBase{Name: name, DueOn: date(due)},
+			Workspace: workspace.ID,
+			Projects:  projects,
+		}
+		if start != "" {
+			request.StartOn = date(start)
+		}
+		task, err := client.CreateTask(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks[name] = task
+	}
+	create("Design", "2024-01-01", "2024-01-02", project.ID)
+	create("Build", "2024-01-01", "2024-01-10", project.ID)
+	create("Docs", "", "2024-01-03")
+	create("Release", "", "2024-01-11", project.ID)
+
+	for _, link := range [][2]string{{"Release", "Design"}, {"Release", "Build"}, {"Build", "Docs"}} {
+		err := tasks[link[0]].AddDependencies(client, &asana.AddDependenciesRequest{Dependencies: []string{tasks[link[1]].ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g, err := project.DependencyGraph(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(order); len(got) != 4 || got[0] != "Design" || got[1] != "Docs" || got[2] != "Build" || got[3] != "Release" {
+		t.Errorf("Unexpected order %v", got)
+	}
+
+	path, days, err := g.CriticalPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(path); len(got) != 3 || got[0] != "Docs" || got[1] != "Build" || got[2] != "Release" || days != 12 {
+		t.Errorf("Unexpected critical path %v (%d days)", got, days)
+	}
+
+	// Closing the loop creates a cycle
+	err = tasks["Docs"].AddDependencies(client, &asana.AddDependenciesRequest{Dependencies: []string{tasks["Release"].ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err = tasks["Design"].DependencyGraph(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycle := g.Cycle(); len(cycle) != 3 {
+		t.Errorf("Expected a cycle of 3 tasks, but saw %v", names(cycle))
+	}
+	if _, err := g.TopologicalOrder(); err == nil {
+		t.Error("Expected a cycle error")
+	} else if _, ok := err.(*asana.DependencyCycleError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	err = tasks["Release"].RemoveDependents(client, &asana.RemoveDependentsRequest{Dependents: []string{tasks["Docs"].ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dependencies, err := tasks["Release"].IterateDependencies(context.Background(), client).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependencies) != 2 {
+		t.Errorf("Unexpected dependencies %v", dependencies)
+	}
+	g, err = asana.CrawlDependencies(context.Background(), client, tasks["Docs"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycle := g.Cycle(); cycle != nil {
+		t.Errorf("Unexpected cycle %v", names(cycle))
+	}
+}
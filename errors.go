@@ -23,17 +23,25 @@ func (r *Response) Error(resp *http.Response, requestID xid.ID) error {
 		}
 	}
 
-	retryHeader := resp.Header.Get("Retry-After")
-	if retryHeader != "" {
-		retryAfter, err := strconv.ParseInt(retryHeader, 10, 64)
-		if err != nil {
-			asanaError.RetryAfter = time.Duration(retryAfter) * time.Second
-		}
-	}
-
+	asanaError.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
 	return asanaError
 }
 
+// parseRetryAfter decodes a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(retryHeader string) time.Duration {
+	if retryHeader == "" {
+		return 0
+	}
+	if retryAfter, err := strconv.ParseInt(retryHeader, 10, 64); err == nil {
+		return time.Duration(retryAfter) * time.Second
+	}
+	if retryTime, err := http.ParseTime(retryHeader); err == nil {
+		return time.Until(retryTime)
+	}
+	return 0
+}
+
 // Error is an error message returned by the API
 type Error struct {
 	StatusCode int
@@ -101,6 +109,16 @@ func IsRateLimited(err error) bool {
 	return false
 }
 
+// IsSyncTokenInvalid checks if the provided error represents a 412 response
+// from the events endpoint, indicating that the sync token was missing,
+// invalid or too old
+func IsSyncTokenInvalid(err error) bool {
+	if e, ok := IsAsanaError(err); ok {
+		return e.StatusCode == 412
+	}
+	return false
+}
+
 // IsRateLimited returns true if the error was a rate limit error
 func IsPayloadTooLarge(err error) bool {
 	if e, ok := IsAsanaError(err); ok {
@@ -1,9 +1,12 @@
 package asana
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/rs/xid"
 )
 
 func TestCauseWrappedError(t *testing.T) {
@@ -22,3 +25,19 @@ func TestCauseWrappedError(t *testing.T) {
 		t.Error("Expected double-wrapped error to be recoverable")
 	}
 }
+
+func TestResponseErrorRetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 429,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}
+
+	err := (&Response{}).Error(resp, xid.New())
+	if !IsRateLimited(err) {
+		t.Fatalf("Expected a rate limit error, but saw %v", err)
+	}
+	if RetryAfter(err) != 30*time.Second {
+		t.Errorf("Expected RetryAfter to be 30s, but saw %s", RetryAfter(err))
+	}
+}
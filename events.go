@@ -0,0 +1,297 @@
+package asana
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// EventAction describes the kind of change an Event represents
+type EventAction string
+
+// Values for Event.Action
+const (
+	EventChanged   EventAction = "changed"
+	EventAdded     EventAction = "added"
+	EventRemoved   EventAction = "removed"
+	EventDeleted   EventAction = "deleted"
+	EventUndeleted EventAction = "undeleted"
+)
+
+// EventChange describes the field which was modified by a changed event
+type EventChange struct {
+	// The name of the field which changed
+	Field string `json:"field,omitempty"`
+
+	// The kind of change to the field: changed, added or removed
+	Action EventAction `json:"action,omitempty"`
+
+	// The new value of the field, for changed fields. The format of the
+	// value depends on the field, so it is left undecoded.
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+
+	// The value added to or removed from a list field
+	AddedValue   json.RawMessage `json:"added_value,omitempty"`
+	RemovedValue json.RawMessage `json:"removed_value,omitempty"`
+}
+
+// Event describes a single change to a resource, as delivered to a webhook
+// or returned by the events endpoint.
+//
+// Events contain only compact references to the affected resources. Use the
+// typed accessors such as Task to obtain an object which can be fetched for
+// full details.
+type Event struct {
+	// The user who triggered the event. May be null for changes made by
+	// Asana itself.
+	User *User `json:"user,omitempty"`
+
+	// The time at which the event was triggered
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// The type of action taken on the resource
+	Action EventAction `json:"action,omitempty"`
+
+	// The resource which was changed
+	Resource *Resource `json:"resource,omitempty"`
+
+	// For added and removed events, the resource the changed resource was
+	// added to or removed from. For stories this is the task being
+	// commented on.
+	Parent *Resource `json:"parent,omitempty"`
+
+	// For changed events, the field which was changed
+	Change *EventChange `json:"change,omitempty"`
+}
+
+// Task returns the changed task, or nil if the resource is not a task
+func (e *Event) Task() *Task {
+	return e.Resource.Task()
+}
+
+// Project returns the changed project, or nil if the resource is not a project
+func (e *Event) Project() *Project {
+	return e.Resource.Project()
+}
+
+// Story returns the changed story, or nil if the resource is not a story.
+// The task the story belongs to is set as its Target where available.
+func (e *Event) Story() *Story {
+	story := e.Resource.Story()
+	if story != nil {
+		story.Target = e.Parent.Task()
+	}
+	return story
+}
+
+// Section returns the changed section, or nil if the resource is not a section
+func (e *Event) Section() *Section {
+	section := e.Resource.Section()
+	if section != nil {
+		section.Project = e.Parent.Project()
+	}
+	return section
+}
+
+type eventsQuery struct {
+	Resource string `url:"resource"`
+	Sync     string `url:"sync,omitempty"`
+}
+
+// EventsResult contains a batch of events returned by the events endpoint
+type EventsResult struct {
+	// The events which occurred since the previous sync token was issued
+	Events []*Event
+
+	// The sync token to use for the next request
+	Sync string
+
+	// True if more events are available immediately
+	HasMore bool
+}
+
+// Events returns the events on a resource since the given sync token was
+// issued. Events are retained by Asana for a limited time, after which the
+// sync token expires.
+//
+// If sync is empty, invalid or expired, the API does not return any events.
+// Instead the result contains a new sync token along with an error for which
+// IsSyncTokenInvalid returns true.
+func (c *Client) Events(resource, sync string, opts ...*Options) (*EventsResult, error) {
+	return c.EventsContext(context.Background(), resource, sync, opts...)
+}
+
+// EventsContext is like Events but uses ctx for the API request
+func (c *Client) EventsContext(ctx context.Context, resource, sync string, opts ...*Options) (*EventsResult, error) {
+	c.trace("Loading events for %s", resource)
+
+	var events []*Event
+
+	// Make the request
+	query := &eventsQuery{
+		Resource: resource,
+		Sync:     sync,
+	}
+	resp, err := c.getResponse(ctx, "/events", query, &events, opts...)
+	if resp == nil {
+		return nil, err
+	}
+
+	return &EventsResult{
+		Events:  events,
+		Sync:    resp.Sync,
+		HasMore: resp.HasMore,
+	}, err
+}
+
+// SyncTokenStore persists the sync tokens used by an EventStream, so that a
+// restarted stream resumes from the last event it delivered
+type SyncTokenStore interface {
+	// LoadSyncToken returns the stored token for a resource, or an empty
+	// string if there is none
+	LoadSyncToken(ctx context.Context, resource string) (string, error)
+
+	// SaveSyncToken stores the token for a resource
+	SaveSyncToken(ctx context.Context, resource, token string) error
+}
+
+// MemorySyncTokenStore is a SyncTokenStore which keeps tokens in memory. The
+// zero value is ready to use.
+type MemorySyncTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// LoadSyncToken implements the SyncTokenStore interface
+func (s *MemorySyncTokenStore) LoadSyncToken(ctx context.Context, resource string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens[resource], nil
+}
+
+// SaveSyncToken implements the SyncTokenStore interface
+func (s *MemorySyncTokenStore) SaveSyncToken(ctx context.Context, resource, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil {
+		s.tokens = make(map[string]string)
+	}
+	s.tokens[resource] = token
+	return nil
+}
+
+// EventStream polls the events endpoint for a resource and delivers the
+// events on a channel.
+//
+// The sync token is saved to the Store after each batch of events has been
+// delivered, so events may be delivered more than once if the stream is
+// interrupted.
+type EventStream struct {
+	// Required: The resource to stream events for
+	Resource string
+
+	// Stores the sync token between polls. Defaults to an in-memory store.
+	Store SyncTokenStore
+
+	// The time to wait between polls when no more events are available.
+	// Defaults to 10 seconds.
+	PollInterval time.Duration
+
+	// Called when the stored sync token was rejected as invalid or expired
+	// and a new token was issued. Any events between the two tokens have
+	// been lost, so consumers may need to resynchronise.
+	OnReset func(resource string)
+
+	// Options passed to each events request
+	Options *Options
+
+	err error
+}
+
+// Start begins polling for events in a new goroutine. The returned channel is
+// closed when ctx is done or a request fails with an unrecoverable error,
+// after which Err reports the cause.
+func (s *EventStream) Start(ctx context.Context, client *Client) <-chan *Event {
+	events := make(chan *Event)
+	go func() {
+		defer close(events)
+		s.err = s.run(ctx, client, events)
+	}()
+	return events
+}
+
+// Err returns the error which stopped the stream, once the event channel has
+// been closed
+func (s *EventStream) Err() error {
+	return s.err
+}
+
+func (s *EventStream) run(ctx context.Context, client *Client, events chan<- *Event) error {
+	store := s.Store
+	if store == nil {
+		store = &MemorySyncTokenStore{}
+	}
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	var opts []*Options
+	if s.Options != nil {
+		opts = append(opts, s.Options)
+	}
+
+	token, err := store.LoadSyncToken(ctx, s.Resource)
+	if err != nil {
+		return err
+	}
+
+	for {
+		result, err := client.EventsContext(ctx, s.Resource, token, opts...)
+		if IsSyncTokenInvalid(err) && result != nil && result.Sync != "" {
+			if token != "" && s.OnReset != nil {
+				s.OnReset(s.Resource)
+			}
+			token = result.Sync
+			if err := store.SaveSyncToken(ctx, s.Resource, token); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !IsRecoverableError(err) && !IsRateLimited(err) {
+				return err
+			}
+			client.info("Polling events for %s failed: %v", s.Resource, err)
+			if err := sleep(ctx, interval); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, event := range result.Events {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		token = result.Sync
+		if err := store.SaveSyncToken(ctx, s.Resource, token); err != nil {
+			return err
+		}
+
+		if !result.HasMore {
+			if err := sleep(ctx, interval); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEventStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch sync := r.URL.Query().Get("sync"); sync {
+		case "", "expired":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"errors":[{"message":"Sync token invalid or too old"}],"sync":"first"}`)
+		case "first":
+			fmt.Fprint(w, `{"data":[{"action":"changed","resource":{"gid":"1","resource_type":"task"}}],"sync":"second","has_more":true}`)
+		case "second":
+			fmt.Fprint(w, `{"data":[{"action":"added","resource":{"gid":"2","resource_type":"section"},"parent":{"gid":"3","resource_type":"project"}}],"sync":"third","has_more":false}`)
+		default:
+			fmt.Fprintf(w, `{"data":[],"sync":%q}`, sync)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	store := &MemorySyncTokenStore{}
+	store.SaveSyncToken(context.Background(), "3", "expired")
+	resets := 0
+	stream := &EventStream{
+		Resource:     "3",
+		Store:        store,
+		PollInterval: time.Millisecond,
+		OnReset:      func(string) { resets++ },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := stream.Start(ctx, client)
+
+	if task := (<-events).Task(); task == nil || task.ID != "1" {
+		t.Errorf("Expected a task event, but saw %+v", task)
+	}
+	if section := (<-events).Section(); section == nil || section.ID != "2" || section.Project.ID != "3" {
+		t.Errorf("Expected a section event, but saw %+v", section)
+	}
+
+	cancel()
+	for range events {
+	}
+	if stream.Err() != context.Canceled {
+		t.Errorf("Expected the stream to stop when cancelled, but saw %v", stream.Err())
+	}
+	if resets != 1 {
+		t.Errorf("Expected 1 reset for the expired token, but saw %d", resets)
+	}
+	if token, _ := store.LoadSyncToken(context.Background(), "3"); token != "third" {
+		t.Errorf("Expected the last sync token to be stored, but saw %q", token)
+	}
+}
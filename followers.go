@@ -0,0 +1,178 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Followers and members are identified by user ID, email address, or "me"
+// for the authenticated user.
+
+// AddFollowers adds users as followers of this task, and returns the updated
+// task. Followers receive notifications when the task changes.
+func (t *Task) AddFollowers(client *Client, followers []string, opts ...*Options) (*Task, error) {
+	return t.AddFollowersContext(context.Background(), client, followers, opts...)
+}
+
+// AddFollowersContext is like AddFollowers but uses ctx for the API request
+func (t *Task) AddFollowersContext(ctx context.Context, client *Client, followers []string, opts ...*Options) (*Task, error) {
+	client.trace("Adding followers to task %q", t.ID)
+
+	result := &Task{}
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/addFollowers", t.ID), followersRequest(followers), result, opts...)
+	return result, err
+}
+
+// RemoveFollowers removes users from the followers of this task, and returns
+// the updated task
+func (t *Task) RemoveFollowers(client *Client, followers []string, opts ...*Options) (*Task, error) {
+	return t.RemoveFollowersContext(context.Background(), client, followers, opts...)
+}
+
+// RemoveFollowersContext is like RemoveFollowers but uses ctx for the API request
+func (t *Task) RemoveFollowersContext(ctx context.Context, client *Client, followers []string, opts ...*Options) (*Task, error) {
+	client.trace("Removing followers from task %q", t.ID)
+
+	result := &Task{}
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/removeFollowers", t.ID), followersRequest(followers), result, opts...)
+	return result, err
+}
+
+// MoveFollowers moves followers from this task to another task, returning
+// both updated tasks. If no followers are given, all current followers of
+// this task are moved.
+//
+// The followers are added to the destination before they are removed from
+// this task, so that they are not lost if either request fails.
+func (t *Task) MoveFollowers(client *Client, to *Task, followers ...string) (from, dest *Task, err error) {
+	return t.MoveFollowersContext(context.Background(), client, to, followers...)
+}
+
+// MoveFollowersContext is like MoveFollowers but uses ctx for the API requests
+func (t *Task) MoveFollowersContext(ctx context.Context, client *Client, to *Task, followers ...string) (from, dest *Task, err error) {
+	client.trace("Moving followers from task %q to %q", t.ID, to.ID)
+
+	if len(followers) == 0 {
+		current := &Task{ID: t.ID}
+		if err := current.FetchContext(ctx, client, &Options{Fields: []string{"followers"}}); err != nil {
+			return nil, nil, err
+		}
+		for _, follower := range current.Followers {
+			followers = append(followers, follower.ID)
+		}
+		if len(followers) == 0 {
+			return current, to, nil
+		}
+	}
+
+	if dest, err = to.AddFollowersContext(ctx, client, followers); err != nil {
+		return nil, nil, errors.Wrap(err, "Add followers")
+	}
+	if from, err = t.RemoveFollowersContext(ctx, client, followers); err != nil {
+		return nil, dest, errors.Wrap(err, "Remove followers")
+	}
+	return from, dest, nil
+}
+
+// AddFollowers adds users as followers of this project, and returns the
+// updated project. Followers are also added as members if they are not
+// already, and receive all notifications for the project.
+func (p *Project) AddFollowers(client *Client, followers []string, opts ...*Options) (*Project, error) {
+	return p.AddFollowersContext(context.Background(), client, followers, opts...)
+}
+
+// AddFollowersContext is like AddFollowers but uses ctx for the API request
+func (p *Project) AddFollowersContext(ctx context.Context, client *Client, followers []string, opts ...*Options) (*Project, error) {
+	client.trace("Adding followers to project %q", p.ID)
+
+	result := &Project{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/addFollowers", p.ID), followersRequest(followers), result, opts...)
+	return result, err
+}
+
+// RemoveFollowers removes users from the followers of this project, and
+// returns the updated project. They remain members of the project.
+func (p *Project) RemoveFollowers(client *Client, followers []string, opts ...*Options) (*Project, error) {
+	return p.RemoveFollowersContext(context.Background(), client, followers, opts...)
+}
+
+// RemoveFollowersContext is like RemoveFollowers but uses ctx for the API request
+func (p *Project) RemoveFollowersContext(ctx context.Context, client *Client, followers []string, opts ...*Options) (*Project, error) {
+	client.trace("Removing followers from project %q", p.ID)
+
+	result := &Project{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/removeFollowers", p.ID), followersRequest(followers), result, opts...)
+	return result, err
+}
+
+// AddMembers adds users as members of this project, and returns the updated
+// project
+func (p *Project) AddMembers(client *Client, members []string, opts ...*Options) (*Project, error) {
+	return p.AddMembersContext(context.Background(), client, members, opts...)
+}
+
+// AddMembersContext is like AddMembers but uses ctx for the API request
+func (p *Project) AddMembersContext(ctx context.Context, client *Client, members []string, opts ...*Options) (*Project, error) {
+	client.trace("Adding members to project %q", p.ID)
+
+	result := &Project{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/addMembers", p.ID), membersRequest(members), result, opts...)
+	return result, err
+}
+
+// RemoveMembers removes users from the members of this project, and returns
+// the updated project. Users who are removed also stop following the
+// project.
+func (p *Project) RemoveMembers(client *Client, members []string, opts ...*Options) (*Project, error) {
+	return p.RemoveMembersContext(context.Background(), client, members, opts...)
+}
+
+// RemoveMembersContext is like RemoveMembers but uses ctx for the API request
+func (p *Project) RemoveMembersContext(ctx context.Context, client *Client, members []string, opts ...*Options) (*Project, error) {
+	client.trace("Removing members from project %q", p.ID)
+
+	result := &Project{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/removeMembers", p.ID), membersRequest(members), result, opts...)
+	return result, err
+}
+
+// AddFollowers queues adding followers to a task
+func (b *Batch) AddFollowers(taskID string, followers []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/addFollowers", taskID), followersRequest(followers), opts...)
+}
+
+// RemoveFollowers queues removing followers from a task
+func (b *Batch) RemoveFollowers(taskID string, followers []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/tasks/%s/removeFollowers", taskID), followersRequest(followers), opts...)
+}
+
+// AddProjectFollowers queues adding followers to a project
+func (b *Batch) AddProjectFollowers(projectID string, followers []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/projects/%s/addFollowers", projectID), followersRequest(followers), opts...)
+}
+
+// RemoveProjectFollowers queues removing followers from a project
+func (b *Batch) RemoveProjectFollowers(projectID string, followers []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/projects/%s/removeFollowers", projectID), followersRequest(followers), opts...)
+}
+
+// AddMembers queues adding members to a project
+func (b *Batch) AddMembers(projectID string, members []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/projects/%s/addMembers", projectID), membersRequest(members), opts...)
+}
+
+// RemoveMembers queues removing members from a project
+func (b *Batch) RemoveMembers(projectID string, members []string, opts ...*Options) *BatchResult {
+	return b.Add(http.MethodPost, fmt.Sprintf("/projects/%s/removeMembers", projectID), membersRequest(members), opts...)
+}
+
+func followersRequest(followers []string) map[string]interface{} {
+	return map[string]interface{}{"followers": followers}
+}
+
+func membersRequest(members []string) map[string]interface{} {
+	return map[string]interface{}{"members": members}
+}
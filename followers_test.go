@@ -0,0 +1,99 @@
+package asana_test
+
+import (
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func userIDs(users []*asana.User) []string {
+	var ids []string
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func TestMoveFollowers(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+	bob := server.AddUser(workspace.ID, "Bob", "bob@example.com")
+	me := server.Me()
+
+	var tasks []*asana.Task
+	for _, name := range []string{"Incident", "Handover"} {
+		task, err := client.CreateTask(&asana.CreateTaskRequest{
+			TaskBase:  asana.TaskBase{Name: name},
+			Workspace: workspace.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	incident, err := tasks[0].AddFollowers(client, []string{alice.ID, "bob@example.com"}, &asana.Options{Fields: []string{"followers"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := userIDs(incident.Followers); len(ids) != 3 {
+		t.Fatalf("Unexpected followers %v", ids)
+	}
+
+	from, to, err := tasks[0].MoveFollowers(client, tasks[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(from.Followers) != 0 {
+		t.Errorf("Followers not removed: %v", userIDs(from.Followers))
+	}
+	if ids := userIDs(to.Followers); len(ids) != 3 || ids[0] != me.ID || ids[1] != alice.ID || ids[2] != bob.ID {
+		t.Errorf("Unexpected followers %v", ids)
+	}
+}
+
+func TestProjectMembers(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Project"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := project.AddFollowers(client, []string{alice.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Followers) != 2 || len(updated.Members) != 2 {
+		t.Errorf("Expected follower to be added as a member: %v %v", userIDs(updated.Followers), userIDs(updated.Members))
+	}
+
+	updated, err = project.RemoveMembers(client, []string{alice.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Followers) != 1 || len(updated.Members) != 1 {
+		t.Errorf("Expected member removal to remove follower: %v %v", userIDs(updated.Followers), userIDs(updated.Members))
+	}
+
+	batch := asana.NewBatch()
+	batch.AddMembers(project.ID, []string{alice.ID})
+	followers := batch.RemoveProjectFollowers(project.ID, []string{"me"})
+	if _, err := batch.Execute(client); err != nil {
+		t.Fatal(err)
+	}
+	result := &asana.Project{}
+	if err := followers.Decode(result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Followers) != 0 || len(result.Members) != 2 {
+		t.Errorf("Unexpected batch result: %v %v", userIDs(result.Followers), userIDs(result.Members))
+	}
+}
@@ -0,0 +1,595 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// GoalStatus is the current status of a goal
+type GoalStatus string
+
+// Goal statuses. Green, yellow and red describe an open goal, and the
+// remaining statuses close the goal.
+const (
+	GoalStatusOnTrack  GoalStatus = "green"
+	GoalStatusAtRisk   GoalStatus = "yellow"
+	GoalStatusOffTrack GoalStatus = "red"
+	GoalStatusMissed   GoalStatus = "missed"
+	GoalStatusAchieved GoalStatus = "achieved"
+	GoalStatusPartial  GoalStatus = "partial"
+	GoalStatusDropped  GoalStatus = "dropped"
+)
+
+// GoalBase contains the modifiable fields for a Goal
+type GoalBase struct {
+	// The name of the goal.
+	Name string `json:"name,omitempty"`
+
+	// Free-form textual information associated with the goal (i.e. its
+	// description).
+	Notes string `json:"notes,omitempty"`
+
+	// The notes of the goal with formatting as HTML.
+	HTMLNotes string `json:"html_notes,omitempty"`
+
+	// The localized day on which this goal is due.
+	DueOn *Date `json:"due_on,omitempty"`
+
+	// The day on which work for this goal begins, or null if the goal has no
+	// start date.
+	StartOn *Date `json:"start_on,omitempty"`
+
+	// True if the goal belongs to the workspace rather than a team.
+	IsWorkspaceLevel *bool `json:"is_workspace_level,omitempty"`
+
+	// The current status of this goal.
+	Status GoalStatus `json:"status,omitempty"`
+}
+
+// Goal is an objective tracked in Asana, with an optional metric measuring
+// progress towards it. Goals belong to a workspace or a team, and can be
+// supported by other goals, projects and portfolios.
+type Goal struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	GoalBase
+
+	// The user who owns this goal.
+	Owner *User `json:"owner,omitempty"`
+
+	// The team this goal belongs to, or null for workspace level goals.
+	Team *Team `json:"team,omitempty"`
+
+	// Create-only. The workspace or organization this goal belongs to.
+	Workspace *Workspace `json:"workspace,omitempty"`
+
+	// The time period this goal is set for.
+	TimePeriod *TimePeriod `json:"time_period,omitempty"`
+
+	// Read-only. Array of users following this goal.
+	Followers []*User `json:"followers,omitempty"`
+
+	// Read-only. The metric measuring progress towards this goal, if any.
+	//
+	// Use SetMetric and SetMetricCurrentValue to change the metric.
+	Metric *GoalMetric `json:"metric,omitempty"`
+
+	// Read-only. The latest status update posted to this goal.
+	CurrentStatusUpdate *Resource `json:"current_status_update,omitempty"`
+
+	// Read-only. The number of users who have liked this goal.
+	NumLikes int32 `json:"num_likes,omitempty"`
+}
+
+// MetricUnit is the unit of the values of a goal metric
+type MetricUnit string
+
+// Metric units
+const (
+	MetricUnitNone       MetricUnit = "none"
+	MetricUnitCurrency   MetricUnit = "currency"
+	MetricUnitPercentage MetricUnit = "percentage"
+)
+
+// ProgressSource defines how the current value of a goal metric is updated
+type ProgressSource string
+
+// Progress sources. Only a manual metric can be set with
+// SetMetricCurrentValue.
+const (
+	ProgressSourceManual                     ProgressSource = "manual"
+	ProgressSourceSubgoalProgress            ProgressSource = "subgoal_progress"
+	ProgressSourceProjectTaskCompletion      ProgressSource = "project_task_completion"
+	ProgressSourceProjectMilestoneCompletion ProgressSource = "project_milestone_completion"
+	ProgressSourceTaskCompletion             ProgressSource = "task_completion"
+	ProgressSourceExternal                   ProgressSource = "external"
+)
+
+// GoalMetricBase contains the modifiable fields for a GoalMetric
+type GoalMetricBase struct {
+	// The number of decimal places shown for the metric values.
+	Precision *int `json:"precision,omitempty"`
+
+	// The unit of the metric values.
+	Unit MetricUnit `json:"unit,omitempty"`
+
+	// ISO 4217 currency code, when the unit is currency.
+	CurrencyCode string `json:"currency_code,omitempty"`
+
+	// The value of the metric when the goal was started.
+	InitialNumberValue *float64 `json:"initial_number_value,omitempty"`
+
+	// The value of the metric at which the goal is achieved.
+	TargetNumberValue *float64 `json:"target_number_value,omitempty"`
+
+	// How the current value of the metric is updated.
+	ProgressSource ProgressSource `json:"progress_source,omitempty"`
+}
+
+// GoalMetric measures progress towards a goal
+type GoalMetric struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The type of metric, which is currently always number.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	GoalMetricBase
+
+	// The current value of the metric.
+	CurrentNumberValue *float64 `json:"current_number_value,omitempty"`
+
+	// Read-only. The current value formatted for display.
+	CurrentDisplayValue string `json:"current_display_value,omitempty"`
+}
+
+// GetID returns the ID of the goal
+func (g *Goal) GetID() string {
+	return g.ID
+}
+
+// Fetch loads the full details for this Goal
+func (g *Goal) Fetch(client *Client, opts ...*Options) error {
+	return g.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (g *Goal) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading goal details for %q", g.Name)
+
+	_, err := client.get(ctx, fmt.Sprintf("/goals/%s", g.ID), nil, g, opts...)
+	return err
+}
+
+// GoalQuery specifies which goals to return from Client.QueryGoals. At least
+// one of Workspace, Team, Portfolio or Project must be given.
+type GoalQuery struct {
+	// Goals in this workspace
+	Workspace string `url:"workspace,omitempty"`
+
+	// Goals belonging to this team
+	Team string `url:"team,omitempty"`
+
+	// Goals supported by this portfolio
+	Portfolio string `url:"portfolio,omitempty"`
+
+	// Goals supported by this project
+	Project string `url:"project,omitempty"`
+
+	// Goals set for any of these time periods
+	TimePeriods []string `url:"time_periods,omitempty,comma"`
+
+	// Only workspace level goals, or only team goals if false
+	IsWorkspaceLevel *bool `url:"is_workspace_level,omitempty"`
+}
+
+// Validate checks the query has at least one of the required filters
+func (q *GoalQuery) Validate() error {
+	if q.Workspace == "" && q.Team == "" && q.Portfolio == "" && q.Project == "" {
+		return errors.New("A workspace, team, portfolio or project is required to list goals")
+	}
+	return nil
+}
+
+// QueryGoals returns the goals matching query
+func (c *Client) QueryGoals(query *GoalQuery, opts ...*Options) ([]*Goal, *NextPage, error) {
+	return c.QueryGoalsContext(context.Background(), query, opts...)
+}
+
+// QueryGoalsContext is like QueryGoals but uses ctx for the API request
+func (c *Client) QueryGoalsContext(ctx context.Context, query *GoalQuery, opts ...*Options) ([]*Goal, *NextPage, error) {
+	c.trace("Query goals %+v\n", query)
+
+	var result []*Goal
+
+	// Make the request
+	nextPage, err := c.get(ctx, "/goals", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateGoals returns an Iterator over the goals matching query
+func (c *Client) IterateGoals(ctx context.Context, query *GoalQuery, opts ...*Options) *Iterator[*Goal] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Goal, *NextPage, error) {
+		return c.QueryGoalsContext(ctx, query, append([]*Options{page}, opts...)...)
+	})
+}
+
+// Goals returns the goals in this workspace
+func (w *Workspace) Goals(client *Client, opts ...*Options) ([]*Goal, *NextPage, error) {
+	return w.GoalsContext(context.Background(), client, opts...)
+}
+
+// GoalsContext is like Goals but uses ctx for the API request
+func (w *Workspace) GoalsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Goal, *NextPage, error) {
+	return client.QueryGoalsContext(ctx, &GoalQuery{Workspace: w.ID}, opts...)
+}
+
+// IterateGoals returns an Iterator over the goals in this workspace
+func (w *Workspace) IterateGoals(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Goal] {
+	return client.IterateGoals(ctx, &GoalQuery{Workspace: w.ID}, opts...)
+}
+
+// Goals returns the goals belonging to this team
+func (t *Team) Goals(client *Client, opts ...*Options) ([]*Goal, *NextPage, error) {
+	return t.GoalsContext(context.Background(), client, opts...)
+}
+
+// GoalsContext is like Goals but uses ctx for the API request
+func (t *Team) GoalsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Goal, *NextPage, error) {
+	return client.QueryGoalsContext(ctx, &GoalQuery{Team: t.ID}, opts...)
+}
+
+// IterateGoals returns an Iterator over the goals belonging to this team
+func (t *Team) IterateGoals(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Goal] {
+	return client.IterateGoals(ctx, &GoalQuery{Team: t.ID}, opts...)
+}
+
+// CreateGoalRequest represents a request to create a goal
+type CreateGoalRequest struct {
+	GoalBase
+
+	// Required: The workspace or organization to create the goal in.
+	Workspace string `json:"workspace"`
+
+	// The team the goal belongs to. Omit for a workspace level goal.
+	Team string `json:"team,omitempty"`
+
+	// The time period the goal is set for.
+	TimePeriod string `json:"time_period,omitempty"`
+
+	// The user who owns the goal. Defaults to the authenticated user.
+	Owner string `json:"owner,omitempty"`
+
+	// Users to add as followers of the goal.
+	Followers []string `json:"followers,omitempty"`
+}
+
+// CreateGoal creates a new goal
+func (c *Client) CreateGoal(request *CreateGoalRequest) (*Goal, error) {
+	return c.CreateGoalContext(context.Background(), request)
+}
+
+// CreateGoalContext is like CreateGoal but uses ctx for the API request
+func (c *Client) CreateGoalContext(ctx context.Context, request *CreateGoalRequest) (*Goal, error) {
+	c.info("Creating goal %q\n", request.Name)
+
+	result := &Goal{}
+	err := c.post(ctx, "/goals", request, result)
+	return result, err
+}
+
+// UpdateGoalRequest represents a request to update a goal
+type UpdateGoalRequest struct {
+	GoalBase
+
+	Team       string `json:"team,omitempty"`
+	TimePeriod string `json:"time_period,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+}
+
+// Update applies new values to a Goal record
+func (g *Goal) Update(client *Client, request *UpdateGoalRequest, opts ...*Options) error {
+	return g.UpdateContext(context.Background(), client, request, opts...)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (g *Goal) UpdateContext(ctx context.Context, client *Client, request *UpdateGoalRequest, opts ...*Options) error {
+	client.trace("Update goal %q", g.Name)
+
+	return client.put(ctx, fmt.Sprintf("/goals/%s", g.ID), request, g, opts...)
+}
+
+// Delete removes this goal
+func (g *Goal) Delete(client *Client) error {
+	return g.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (g *Goal) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting goal %q", g.Name)
+
+	return client.delete(ctx, fmt.Sprintf("/goals/%s", g.ID))
+}
+
+// SetMetric creates or replaces the metric measuring progress towards this
+// goal, and updates the goal with the result
+func (g *Goal) SetMetric(client *Client, metric *GoalMetricBase, opts ...*Options) error {
+	return g.SetMetricContext(context.Background(), client, metric, opts...)
+}
+
+// SetMetricContext is like SetMetric but uses ctx for the API request
+func (g *Goal) SetMetricContext(ctx context.Context, client *Client, metric *GoalMetricBase, opts ...*Options) error {
+	client.trace("Set metric of goal %q", g.ID)
+
+	request := &setMetricRequest{MetricType: "number", GoalMetricBase: metric}
+	return client.post(ctx, fmt.Sprintf("/goals/%s/setMetric", g.ID), request, g, opts...)
+}
+
+// setMetricRequest gives the metric fields directly in the request data
+type setMetricRequest struct {
+	// The type of metric, which is currently always number.
+	MetricType string `json:"metric_type"`
+
+	*GoalMetricBase
+}
+
+// SetMetricCurrentValue updates the current value of this goal's metric,
+// and updates the goal with the result. The goal must have a manual metric.
+func (g *Goal) SetMetricCurrentValue(client *Client, value float64, opts ...*Options) error {
+	return g.SetMetricCurrentValueContext(context.Background(), client, value, opts...)
+}
+
+// SetMetricCurrentValueContext is like SetMetricCurrentValue but uses ctx for the API request
+func (g *Goal) SetMetricCurrentValueContext(ctx context.Context, client *Client, value float64, opts ...*Options) error {
+	client.trace("Set current metric value of goal %q to %v", g.ID, value)
+
+	return client.post(ctx, fmt.Sprintf("/goals/%s/setMetricCurrentValue", g.ID), map[string]interface{}{
+		"current_number_value": value,
+	}, g, opts...)
+}
+
+// ParentGoals returns the goals which this goal supports
+func (g *Goal) ParentGoals(client *Client, opts ...*Options) ([]*Goal, error) {
+	return g.ParentGoalsContext(context.Background(), client, opts...)
+}
+
+// ParentGoalsContext is like ParentGoals but uses ctx for the API request
+func (g *Goal) ParentGoalsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Goal, error) {
+	client.trace("Listing parent goals of goal %q", g.ID)
+
+	var result []*Goal
+
+	// Make the request
+	_, err := client.get(ctx, fmt.Sprintf("/goals/%s/parentGoals", g.ID), nil, &result, opts...)
+	return result, err
+}
+
+// Goal relationship subtypes
+const (
+	GoalRelationshipSubgoal        = "subgoal"
+	GoalRelationshipSupportingWork = "supporting_work"
+)
+
+// GoalRelationship links a goal to a goal, project, portfolio or task which
+// supports it
+type GoalRelationship struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. Either subgoal or supporting_work.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// Read-only. The goal, project, portfolio or task supporting the goal.
+	SupportingResource *Resource `json:"supporting_resource,omitempty"`
+
+	// Read-only. The goal being supported.
+	SupportedGoal *Goal `json:"supported_goal,omitempty"`
+
+	// The weight of the supporting resource's progress in the progress of
+	// the supported goal, between 0 and 1.
+	ContributionWeight *float64 `json:"contribution_weight,omitempty"`
+}
+
+// Fetch loads the full details for this GoalRelationship
+func (r *GoalRelationship) Fetch(client *Client, opts ...*Options) error {
+	return r.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (r *GoalRelationship) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading goal relationship details for %q", r.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/goal_relationships/%s", r.ID), nil, r, opts...)
+	return err
+}
+
+// SetContributionWeight changes how much the supporting resource
+// contributes to the progress of the supported goal
+func (r *GoalRelationship) SetContributionWeight(client *Client, weight float64, opts ...*Options) error {
+	return r.SetContributionWeightContext(context.Background(), client, weight, opts...)
+}
+
+// SetContributionWeightContext is like SetContributionWeight but uses ctx for the API request
+func (r *GoalRelationship) SetContributionWeightContext(ctx context.Context, client *Client, weight float64, opts ...*Options) error {
+	client.trace("Set contribution weight of goal relationship %q to %v", r.ID, weight)
+
+	return client.put(ctx, fmt.Sprintf("/goal_relationships/%s", r.ID), map[string]interface{}{
+		"contribution_weight": weight,
+	}, r, opts...)
+}
+
+type goalRelationshipsQuery struct {
+	SupportedGoal   string `url:"supported_goal"`
+	ResourceSubtype string `url:"resource_subtype,omitempty"`
+}
+
+// Relationships returns the relationships to the goals, projects,
+// portfolios and tasks which support this goal. If subtype is not empty only
+// relationships of that subtype are returned.
+func (g *Goal) Relationships(client *Client, subtype string, opts ...*Options) ([]*GoalRelationship, *NextPage, error) {
+	return g.RelationshipsContext(context.Background(), client, subtype, opts...)
+}
+
+// RelationshipsContext is like Relationships but uses ctx for the API request
+func (g *Goal) RelationshipsContext(ctx context.Context, client *Client, subtype string, opts ...*Options) ([]*GoalRelationship, *NextPage, error) {
+	client.trace("Listing relationships of goal %q", g.ID)
+
+	var result []*GoalRelationship
+
+	// Make the request
+	query := &goalRelationshipsQuery{
+		SupportedGoal:   g.ID,
+		ResourceSubtype: subtype,
+	}
+	nextPage, err := client.get(ctx, "/goal_relationships", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateRelationships returns an Iterator over the relationships of this
+// goal with the given subtype, or all relationships if subtype is empty
+func (g *Goal) IterateRelationships(ctx context.Context, client *Client, subtype string, opts ...*Options) *Iterator[*GoalRelationship] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*GoalRelationship, *NextPage, error) {
+		return g.RelationshipsContext(ctx, client, subtype, append([]*Options{page}, opts...)...)
+	})
+}
+
+// SupportingGoals returns all of the goals which support this goal
+func (g *Goal) SupportingGoals(client *Client) ([]*Goal, error) {
+	return g.SupportingGoalsContext(context.Background(), client)
+}
+
+// SupportingGoalsContext is like SupportingGoals but uses ctx for the API requests
+func (g *Goal) SupportingGoalsContext(ctx context.Context, client *Client) ([]*Goal, error) {
+	relationships, err := g.IterateRelationships(ctx, client, GoalRelationshipSubgoal).All()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Goal
+	for _, relationship := range relationships {
+		if goal := relationship.SupportingResource.Goal(); goal != nil {
+			result = append(result, goal)
+		}
+	}
+	return result, nil
+}
+
+// AddSupportingRelationshipRequest defines the resource supporting a goal
+// and its position among the goal's existing supporting resources
+type AddSupportingRelationshipRequest struct {
+	// Required: The goal, project, portfolio or task supporting the goal.
+	SupportingResource string `json:"supporting_resource"`
+
+	InsertBefore string `json:"insert_before,omitempty"`
+	InsertAfter  string `json:"insert_after,omitempty"`
+
+	// The weight of the supporting resource's progress in the progress of
+	// the goal, between 0 and 1.
+	ContributionWeight *float64 `json:"contribution_weight,omitempty"`
+}
+
+// AddSupportingRelationship links a goal, project, portfolio or task to
+// this goal as supporting it
+func (g *Goal) AddSupportingRelationship(client *Client, request *AddSupportingRelationshipRequest) (*GoalRelationship, error) {
+	return g.AddSupportingRelationshipContext(context.Background(), client, request)
+}
+
+// AddSupportingRelationshipContext is like AddSupportingRelationship but uses ctx for the API request
+func (g *Goal) AddSupportingRelationshipContext(ctx context.Context, client *Client, request *AddSupportingRelationshipRequest) (*GoalRelationship, error) {
+	client.trace("Adding %q as supporting goal %q", request.SupportingResource, g.ID)
+
+	result := &GoalRelationship{}
+	err := client.post(ctx, fmt.Sprintf("/goals/%s/addSupportingRelationship", g.ID), request, result)
+	return result, err
+}
+
+// RemoveSupportingRelationship removes the link between this goal and a
+// goal, project, portfolio or task supporting it
+func (g *Goal) RemoveSupportingRelationship(client *Client, supportingResource string) error {
+	return g.RemoveSupportingRelationshipContext(context.Background(), client, supportingResource)
+}
+
+// RemoveSupportingRelationshipContext is like RemoveSupportingRelationship but uses ctx for the API request
+func (g *Goal) RemoveSupportingRelationshipContext(ctx context.Context, client *Client, supportingResource string) error {
+	client.trace("Removing %q as supporting goal %q", supportingResource, g.ID)
+
+	return client.post(ctx, fmt.Sprintf("/goals/%s/removeSupportingRelationship", g.ID), map[string]interface{}{
+		"supporting_resource": supportingResource,
+	}, nil)
+}
+
+// TimePeriod is a fiscal year, half or quarter in a workspace, which goals
+// can be set for
+type TimePeriod struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The first day of the time period.
+	StartOn *Date `json:"start_on,omitempty"`
+
+	// Read-only. The last day of the time period.
+	EndOn *Date `json:"end_on,omitempty"`
+
+	// Read-only. The kind of period: FY, H1, H2, Q1, Q2, Q3 or Q4.
+	Period string `json:"period,omitempty"`
+
+	// Read-only. A name for the time period, such as "Q1 FY24".
+	DisplayName string `json:"display_name,omitempty"`
+
+	// Read-only. The time period containing this one, such as the fiscal
+	// year of a quarter.
+	Parent *TimePeriod `json:"parent,omitempty"`
+}
+
+// Fetch loads the full details for this TimePeriod
+func (t *TimePeriod) Fetch(client *Client, opts ...*Options) error {
+	return t.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (t *TimePeriod) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading time period details for %q", t.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/time_periods/%s", t.ID), nil, t, opts...)
+	return err
+}
+
+type timePeriodsQuery struct {
+	Workspace string `url:"workspace"`
+	StartOn   *Date  `url:"start_on,omitempty"`
+	EndOn     *Date  `url:"end_on,omitempty"`
+}
+
+// TimePeriods returns the time periods in this workspace. If startOn or
+// endOn are given, only time periods which start on or after startOn and end
+// on or before endOn are returned.
+func (w *Workspace) TimePeriods(client *Client, startOn, endOn *Date, opts ...*Options) ([]*TimePeriod, *NextPage, error) {
+	return w.TimePeriodsContext(context.Background(), client, startOn, endOn, opts...)
+}
+
+// TimePeriodsContext is like TimePeriods but uses ctx for the API request
+func (w *Workspace) TimePeriodsContext(ctx context.Context, client *Client, startOn, endOn *Date, opts ...*Options) ([]*TimePeriod, *NextPage, error) {
+	client.trace("Listing time periods in %q", w.Name)
+
+	var result []*TimePeriod
+
+	// Make the request
+	query := &timePeriodsQuery{
+		Workspace: w.ID,
+		StartOn:   startOn,
+		EndOn:     endOn,
+	}
+	nextPage, err := client.get(ctx, "/time_periods", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateTimePeriods returns an Iterator over the time periods in this
+// workspace, filtered as for TimePeriods
+func (w *Workspace) IterateTimePeriods(ctx context.Context, client *Client, startOn, endOn *Date, opts ...*Options) *Iterator[*TimePeriod] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*TimePeriod, *NextPage, error) {
+		return w.TimePeriodsContext(ctx, client, startOn, endOn, append([]*Options{page}, opts...)...)
+	})
+}
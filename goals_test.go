@@ -0,0 +1,209 @@
+package asana_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestGoals(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddOrganization("Organization")
+	team := server.AddTeam(workspace.ID, "Engineering")
+	year := server.AddTimePeriod(workspace.ID, "FY", "2024-01-01", "2024-12-31")
+	quarter := server.AddTimePeriod(workspace.ID, "Q1", "2024-01-01", "2024-03-31")
+	if quarter.Parent == nil || quarter.Parent.ID != year.ID {
+		t.Errorf("Unexpected parent %v", quarter.Parent)
+	}
+
+	periods, _, err := workspace.TimePeriods(client, date("2024-01-01"), date("2024-06-30"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(periods) != 1 || periods[0].ID != quarter.ID {
+		t.Errorf("Unexpected time periods %v", periods)
+	}
+
+	company, err := client.CreateGoal(&asana.CreateGoalRequest{
+		GoalBase:   asana.GoalBase{Name: "Grow revenue"},
+		Workspace:  workspace.ID,
+		TimePeriod: year.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goal, err := client.CreateGoal(&asana.CreateGoalRequest{
+		GoalBase:   asana.GoalBase{Name: "Ship billing"},
+		Workspace:  workspace.ID,
+		Team:       team.ID,
+		TimePeriod: quarter.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goal.Team == nil || goal.Team.ID != team.ID || asana.IsTrue(goal.IsWorkspaceLevel) {
+		t.Errorf("Unexpected team %v", goal.Team)
+	}
+
+	goals, _, err := team.Goals(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goals) != 1 || goals[0].ID != goal.ID {
+		t.Errorf("Unexpected team goals %v", goals)
+	}
+	goals, err = client.IterateGoals(context.Background(), &asana.GoalQuery{
+		Workspace:   workspace.ID,
+		TimePeriods: []string{year.ID},
+	}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goals) != 1 || goals[0].ID != company.ID {
+		t.Errorf("Unexpected goals for %s: %v", year.DisplayName, goals)
+	}
+	if _, _, err := client.QueryGoals(&asana.GoalQuery{}); err == nil {
+		t.Error("Expected a validation error")
+	}
+
+	// Metrics
+	target := 100000.0
+	err = company.SetMetric(client, &asana.GoalMetricBase{
+		Unit:              asana.MetricUnitCurrency,
+		CurrencyCode:      "USD",
+		TargetNumberValue: &target,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := company.SetMetricCurrentValue(client, 25000); err != nil {
+		t.Fatal(err)
+	}
+	if m := company.Metric; m == nil || *m.CurrentNumberValue != 25000 || m.CurrentDisplayValue != "25000 USD" {
+		t.Errorf("Unexpected metric %+v", m)
+	}
+
+	// Relationships
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Billing"},
+		Team:        team.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := company.AddSupportingRelationship(client, &asana.AddSupportingRelationshipRequest{SupportingResource: project.ID}); err != nil {
+		t.Fatal(err)
+	}
+	relationship, err := company.AddSupportingRelationship(client, &asana.AddSupportingRelationshipRequest{
+		SupportingResource: goal.ID,
+		InsertBefore:       project.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if relationship.ResourceSubtype != asana.GoalRelationshipSubgoal {
+		t.Errorf("Unexpected relationship %+v", relationship)
+	}
+	if err := relationship.SetContributionWeight(client, 0.5); err != nil {
+		t.Fatal(err)
+	}
+
+	relationships, _, err := company.Relationships(client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relationships) != 2 || relationships[0].SupportingResource.Goal() == nil || relationships[1].SupportingResource.Project() == nil {
+		t.Errorf("Unexpected relationships %v", relationships)
+	}
+	if w := relationships[0].ContributionWeight; w == nil || *w != 0.5 {
+		t.Errorf("Unexpected contribution weight %v", w)
+	}
+	supporting, err := company.SupportingGoals(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(supporting) != 1 || supporting[0].Name != "Ship billing" {
+		t.Errorf("Unexpected supporting goals %v", supporting)
+	}
+	parents, err := goal.ParentGoals(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parents) != 1 || parents[0].ID != company.ID {
+		t.Errorf("Unexpected parent goals %v", parents)
+	}
+	goals, _, err = client.QueryGoals(&asana.GoalQuery{Project: project.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goals) != 1 || goals[0].ID != company.ID {
+		t.Errorf("Unexpected goals supported by project %v", goals)
+	}
+
+	if err := company.RemoveSupportingRelationship(client, goal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := goal.Update(client, &asana.UpdateGoalRequest{GoalBase: asana.GoalBase{Status: asana.GoalStatusAchieved}}); err != nil {
+		t.Fatal(err)
+	}
+	if goal.Status != asana.GoalStatusAchieved {
+		t.Errorf("Unexpected status %q", goal.Status)
+	}
+	if err := goal.Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := goal.Fetch(client); !asana.IsNotFoundError(err) {
+		t.Errorf("Expected not found, but saw %v", err)
+	}
+}
+
+func TestGoalSetMetricRequest(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/goals/1/setMetric" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, `{"data":{"gid":"1"}}`)
+	}))
+	defer server.Close()
+	client := asana.NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	precision, target := 2, 50.0
+	err := (&asana.Goal{ID: "1"}).SetMetric(client, &asana.GoalMetricBase{
+		Precision:         &precision,
+		Unit:              asana.MetricUnitCurrency,
+		CurrencyCode:      "EUR",
+		TargetNumberValue: &target,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := body["data"]
+	expected := map[string]interface{}{
+		"metric_type":         "number",
+		"precision":           2.0,
+		"unit":                "currency",
+		"currency_code":       "EUR",
+		"target_number_value": 50.0,
+	}
+	if len(data) != len(expected) {
+		t.Errorf("Expected the metric fields in the request data, but saw %v", data)
+	}
+	for key, value := range expected {
+		if data[key] != value {
+			t.Errorf("Expected %s %v, but saw %v", key, value, data[key])
+		}
+	}
+}
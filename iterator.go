@@ -0,0 +1,187 @@
+package asana
+
+import (
+	"context"
+)
+
+// A PageFunc loads a single page of results from a list endpoint, using the
+// Limit and Offset provided in page
+type PageFunc[T any] func(ctx context.Context, page *Options) ([]T, *NextPage, error)
+
+type pageResult[T any] struct {
+	items []T
+	next  *NextPage
+	err   error
+}
+
+// Iterator lazily pages through the results of a list endpoint, following
+// the NextPage offsets returned by the API. Pages are only requested as the
+// items are consumed, so results can be streamed without holding every item
+// in memory.
+//
+// Typical use is:
+//
+//	it := project.IterateTasks(ctx, client)
+//	defer it.Close()
+//	for it.Next() {
+//		task := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// The configuration fields must be set before the first call to Next. An
+// Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	// The number of items requested in each page, between 1 and 100.
+	// Defaults to 100.
+	PageSize int
+
+	// The maximum number of items to return. Zero means no limit.
+	MaxItems int
+
+	// When true, the next page is requested in the background while the
+	// items of the current page are consumed.
+	Prefetch bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  PageFunc[T]
+
+	page       []T
+	value      T
+	count      int
+	more       bool
+	nextOffset string
+	pending    chan pageResult[T]
+	err        error
+}
+
+// NewIterator creates an Iterator over the pages returned by fetch. Requests
+// are made using ctx, and stop if it is cancelled.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T]) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Iterator[T]{
+		ctx:    ctx,
+		cancel: cancel,
+		fetch:  fetch,
+		more:   true,
+	}
+}
+
+// Next advances to the next item, loading the next page if required. It
+// returns false when there are no more items or an error occurs.
+func (it *Iterator[T]) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fill() {
+			return false
+		}
+	}
+
+	it.value = it.page[0]
+	it.page = it.page[1:]
+	it.count++
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the first error encountered while loading a page
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and cancels any page request in progress. It is
+// safe to call Close more than once.
+func (it *Iterator[T]) Close() {
+	it.more = false
+	it.page = nil
+	it.pending = nil
+	it.cancel()
+}
+
+// All consumes the remaining items and returns them in a single slice,
+// which is empty rather than nil if there are no items
+func (it *Iterator[T]) All() ([]T, error) {
+	defer it.Close()
+
+	items := []T{}
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+	return items, nil
+}
+
+// fill loads the next page of items, returning false if there are none
+func (it *Iterator[T]) fill() bool {
+	if !it.more || (it.MaxItems > 0 && it.count >= it.MaxItems) {
+		it.Close()
+		return false
+	}
+
+	var result pageResult[T]
+	if it.pending != nil {
+		result = <-it.pending
+		it.pending = nil
+	} else {
+		result = it.load(it.nextOffset, it.limit(0))
+	}
+
+	if result.err != nil {
+		it.err = result.err
+		it.Close()
+		return false
+	}
+
+	it.page = result.items
+	if it.MaxItems > 0 && it.count+len(it.page) > it.MaxItems {
+		it.page = it.page[:it.MaxItems-it.count]
+	}
+
+	if result.next == nil || result.next.Offset == "" {
+		it.more = false
+	} else {
+		it.nextOffset = result.next.Offset
+	}
+
+	// Start loading the following page
+	if it.Prefetch && it.more && (it.MaxItems == 0 || it.count+len(it.page) < it.MaxItems) {
+		pending := make(chan pageResult[T], 1)
+		offset, limit := it.nextOffset, it.limit(len(it.page))
+		go func() {
+			pending <- it.load(offset, limit)
+		}()
+		it.pending = pending
+	}
+	return true
+}
+
+// limit returns the page size to request, given the number of items already
+// loaded but not yet consumed
+func (it *Iterator[T]) limit(buffered int) int {
+	limit := it.PageSize
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	if it.MaxItems > 0 {
+		if remaining := it.MaxItems - it.count - buffered; remaining < limit {
+			limit = remaining
+		}
+	}
+	return limit
+}
+
+func (it *Iterator[T]) load(offset string, limit int) pageResult[T] {
+	items, next, err := it.fetch(it.ctx, &Options{
+		Limit:  limit,
+		Offset: offset,
+	})
+	return pageResult[T]{items: items, next: next, err: err}
+}
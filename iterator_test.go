@@ -0,0 +1,116 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// pages returns a PageFunc serving n items, recording the requested limits
+func pages(n int, limits *[]int) PageFunc[int] {
+	return func(ctx context.Context, page *Options) ([]int, *NextPage, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		*limits = append(*limits, page.Limit)
+
+		start := 0
+		if page.Offset != "" {
+			start, _ = strconv.Atoi(page.Offset)
+		}
+		var items []int
+		for i := start; i < n && i < start+page.Limit; i++ {
+			items = append(items, i)
+		}
+		if start+len(items) >= n {
+			return items, nil, nil
+		}
+		return items, &NextPage{Offset: fmt.Sprint(start + len(items))}, nil
+	}
+}
+
+func TestIteratorAll(t *testing.T) {
+	var limits []int
+	it := NewIterator(context.Background(), pages(25, &limits))
+	it.PageSize = 10
+
+	items, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 25 || items[24] != 24 {
+		t.Errorf("Expected 25 items in order, but saw %v", items)
+	}
+	if len(limits) != 3 {
+		t.Errorf("Expected 3 page requests, but saw %d", len(limits))
+	}
+}
+
+func TestIteratorAllEmpty(t *testing.T) {
+	var limits []int
+	items, err := NewIterator(context.Background(), pages(0, &limits)).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items == nil || len(items) != 0 {
+		t.Errorf("Expected an empty slice, but saw %#v", items)
+	}
+}
+
+func TestIteratorMaxItems(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		var limits []int
+		it := NewIterator(context.Background(), pages(100, &limits))
+		it.PageSize = 10
+		it.MaxItems = 15
+		it.Prefetch = prefetch
+
+		items, err := it.All()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 15 {
+			t.Errorf("Expected 15 items with prefetch %v, but saw %d", prefetch, len(items))
+		}
+		if fmt.Sprint(limits) != "[10 5]" {
+			t.Errorf("Expected page limits [10 5] with prefetch %v, but saw %v", prefetch, limits)
+		}
+	}
+}
+
+func TestIteratorEarlyTermination(t *testing.T) {
+	var limits []int
+	it := NewIterator(context.Background(), pages(100, &limits))
+	it.PageSize = 10
+
+	for it.Next() {
+		if it.Value() == 12 {
+			break
+		}
+	}
+	it.Close()
+
+	if it.Next() {
+		t.Error("Expected no more items after Close")
+	}
+	if len(limits) != 2 {
+		t.Errorf("Expected 2 page requests, but saw %d", len(limits))
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	fail := errors.New("failed")
+	it := NewIterator(context.Background(), func(ctx context.Context, page *Options) ([]int, *NextPage, error) {
+		if page.Offset == "" {
+			return []int{1}, &NextPage{Offset: "1"}, nil
+		}
+		return nil, nil, fail
+	})
+
+	if _, err := it.All(); err != fail {
+		t.Errorf("Expected the page error, but saw %v", err)
+	}
+}
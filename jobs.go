@@ -0,0 +1,107 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// JobStatus is the state of an asynchronous job
+type JobStatus string
+
+// Job statuses. A job which has succeeded or failed will not change again.
+const (
+	JobNotStarted JobStatus = "not_started"
+	JobInProgress JobStatus = "in_progress"
+	JobSucceeded  JobStatus = "succeeded"
+	JobFailed     JobStatus = "failed"
+)
+
+// The delay before polling a job again, which doubles after each poll until
+// the maximum is reached
+var (
+	jobPollInterval    = 500 * time.Millisecond
+	jobMaxPollInterval = 10 * time.Second
+)
+
+// Job is an asynchronous operation, such as duplicating a project or
+// instantiating a template. The object created by the job is available once
+// the job has succeeded.
+type Job struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The kind of job, such as duplicate_project.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// Read-only. The current state of the job.
+	Status JobStatus `json:"status,omitempty"`
+
+	// Read-only. The project created by the job, if any.
+	NewProject *Project `json:"new_project,omitempty"`
+
+	// Read-only. The task created by the job, if any.
+	NewTask *Task `json:"new_task,omitempty"`
+
+	// Read-only. The project template created by the job, if any.
+	NewProjectTemplate *ProjectTemplate `json:"new_project_template,omitempty"`
+}
+
+// JobFailedError is returned when waiting for a job which fails
+type JobFailedError struct {
+	Job *Job
+}
+
+func (e *JobFailedError) Error() string {
+	return fmt.Sprintf("Job %s (%s) failed", e.Job.ID, e.Job.ResourceSubtype)
+}
+
+// Done returns true if the job has succeeded or failed
+func (j *Job) Done() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
+}
+
+// Fetch loads the current state of this Job
+func (j *Job) Fetch(client *Client, opts ...*Options) error {
+	return j.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (j *Job) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading job details for %q", j.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/jobs/%s", j.ID), nil, j, opts...)
+	return err
+}
+
+// Wait polls the job until it succeeds or fails, updating this Job with the
+// final state. A *JobFailedError is returned if the job fails.
+func (j *Job) Wait(client *Client) error {
+	return j.WaitContext(context.Background(), client)
+}
+
+// WaitContext is like Wait but uses ctx for the API requests. Polling stops
+// with the context's error if ctx is done first.
+func (j *Job) WaitContext(ctx context.Context, client *Client) error {
+	client.trace("Waiting for job %q", j.ID)
+
+	delay := jobPollInterval
+	for {
+		if err := j.FetchContext(ctx, client); err != nil {
+			return err
+		}
+		switch j.Status {
+		case JobSucceeded:
+			return nil
+		case JobFailed:
+			return &JobFailedError{Job: j}
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		if delay *= 2; delay > jobMaxPollInterval {
+			delay = jobMaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package asana_test
+
+import (
+	"testing"
+	"time"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestDuplicateProject(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Onboarding", Notes: "Client onboarding"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kickoff, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase:  asana.TaskBase{Name: "Kickoff", Notes: "Agenda", StartOn: date("2024-01-01"), DueOn: date("2024-01-02")},
+		Assignee:  alice.ID,
+		Projects:  []string{project.ID},
+		Workspace: workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handover, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Handover", DueOn: date("2024-01-05")},
+		Projects: []string{project.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Book room"},
+		Parent:   kickoff.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err = handover.AddDependencies(client, &asana.AddDependenciesRequest{Dependencies: []string{kickoff.ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := project.Duplicate(client, &asana.DuplicateProjectRequest{Name: "Copy", ScheduleDates: &asana.ScheduleDates{}}); err == nil {
+		t.Error("Expected a validation error")
+	}
+	job, err := project.Duplicate(client, &asana.DuplicateProjectRequest{
+		Name: "Onboarding: Acme",
+		Include: []asana.ProjectDuplicateOption{
+			asana.ProjectDuplicateNotes,
+			asana.ProjectDuplicateTaskNotes,
+			asana.ProjectDuplicateTaskSubtasks,
+			asana.ProjectDuplicateTaskDates,
+			asana.ProjectDuplicateTaskDependencies,
+		},
+		ScheduleDates: &asana.ScheduleDates{StartOn: date("2024-03-01"), ShouldSkipWeekends: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Done() || job.NewProject == nil {
+		t.Errorf("Unexpected job %+v", job)
+	}
+	if err := job.Wait(client); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != asana.JobSucceeded || job.NewProject.Name != "Onboarding: Acme" {
+		t.Errorf("Unexpected job %+v", job)
+	}
+
+	copied := job.NewProject
+	if err := copied.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if copied.Notes != "Client onboarding" {
+		t.Errorf("Notes not copied: %q", copied.Notes)
+	}
+	tasks, _, err := copied.Tasks(client, &asana.Options{Fields: []string{"name", "notes", "assignee", "start_on", "due_on", "dependencies", "num_subtasks"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Unexpected tasks %v", names(tasks))
+	}
+	// Dates move by 60 days, and 2024-03-05 is a Tuesday
+	first, second := tasks[0], tasks[1]
+	if first.Notes != "Agenda" || first.Assignee != nil || first.NumSubtasks != 1 {
+		t.Errorf("Unexpected copy %+v", first)
+	}
+	if got := time.Time(*first.StartOn).Format("2006-01-02"); got != "2024-03-01" {
+		t.Errorf("Unexpected start date %s", got)
+	}
+	if got := time.Time(*second.DueOn).Format("2006-01-02"); got != "2024-03-05" {
+		t.Errorf("Unexpected due date %s", got)
+	}
+	if len(second.Dependencies) != 1 || second.Dependencies[0].ID != first.ID {
+		t.Errorf("Dependencies not copied to the new tasks: %v", second.Dependencies)
+	}
+
+	server.FailNextJob()
+	job, err = project.Duplicate(client, &asana.DuplicateProjectRequest{Name: "Failed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := job.Wait(client); err == nil {
+		t.Error("Expected the job to fail")
+	} else if _, ok := err.(*asana.JobFailedError); !ok {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestProjectTemplates(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddOrganization("Organization")
+	team := server.AddTeam(workspace.ID, "Services")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Client project"},
+		Team:        team.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Welcome call", DueOn: date("2024-01-03")},
+		Projects: []string{project.ID},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	server.AddProjectTemplate(project.ID, []string{"Start date"}, []string{"Account manager"})
+
+	templates, _, err := team.ProjectTemplates(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("Unexpected templates %v", templates)
+	}
+	template := templates[0]
+	if err := template.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if len(template.RequestedDates) != 1 || len(template.RequestedRoles) != 1 || template.RequestedRoles[0].Name != "Account manager" {
+		t.Errorf("Unexpected template %+v", template)
+	}
+
+	job, err := template.Instantiate(client, &asana.InstantiateProjectRequest{
+		Name:           "Acme",
+		RequestedDates: []*asana.DateVariableValue{{ID: template.RequestedDates[0].ID, Value: date("2024-02-01")}},
+		RequestedRoles: []*asana.RoleAssignment{{ID: template.RequestedRoles[0].ID, Value: alice.ID}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := job.Wait(client); err != nil {
+		t.Fatal(err)
+	}
+	created := job.NewProject
+	if err := created.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "Acme" || len(created.Members) != 2 || created.Team == nil || created.Team.ID != team.ID {
+		t.Errorf("Unexpected project %+v", created)
+	}
+	tasks, _, err := created.Tasks(client, &asana.Options{Fields: []string{"name", "due_on"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || time.Time(*tasks[0].DueOn).Format("2006-01-02") != "2024-02-01" {
+		t.Errorf("Unexpected tasks %v", names(tasks))
+	}
+}
+
+func TestDuplicateTask(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Checklists"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase:  asana.TaskBase{Name: "Monthly close", Notes: "Checklist"},
+		Projects:  []string{project.ID},
+		Followers: []string{alice.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Reconcile accounts"},
+		Parent:   template.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := template.Duplicate(client, &asana.DuplicateTaskRequest{}); err == nil {
+		t.Error("Expected a validation error")
+	}
+	task, err := template.DuplicateAndWait(client, &asana.DuplicateTaskRequest{
+		Name: "January close",
+		Include: []asana.TaskDuplicateOption{
+			asana.TaskDuplicateNotes,
+			asana.TaskDuplicateSubtasks,
+			asana.TaskDuplicateFollowers,
+			asana.TaskDuplicateProjects,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if task.Name != "January close" || task.Notes != "Checklist" || task.NumSubtasks != 1 || len(task.Followers) != 2 {
+		t.Errorf("Unexpected task %+v", task)
+	}
+	if len(task.Projects) != 1 || task.Projects[0].ID != project.ID {
+		t.Errorf("Unexpected projects %v", task.Projects)
+	}
+
+	// Without options only the name is copied
+	task, err = template.DuplicateAndWait(client, &asana.DuplicateTaskRequest{Name: "February close"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if task.Notes != "" || task.NumSubtasks != 0 || len(task.Projects) != 0 {
+		t.Errorf("Unexpected task %+v", task)
+	}
+}
@@ -51,13 +51,21 @@ func (a *App) AuthCodeURL(state string) string {
 
 // see oauth2 package
 func (a *App) Exchange(code string) (*oauth2.Token, error) {
-	ctx := context.Background()
+	return a.ExchangeContext(context.Background(), code)
+}
+
+// ExchangeContext is like Exchange but uses ctx for the token request
+func (a *App) ExchangeContext(ctx context.Context, code string) (*oauth2.Token, error) {
 	return a.config.Exchange(ctx, code)
 }
 
 // see oauth2 package
 func (a *App) Refresh(token *oauth2.Token) (*oauth2.Token, error) {
-	ctx := context.Background()
+	return a.RefreshContext(context.Background(), token)
+}
+
+// RefreshContext is like Refresh but uses ctx for the token request
+func (a *App) RefreshContext(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
 	invalidToken := *token
 	invalidToken.Expiry = time.Time{}
 	ts := a.config.TokenSource(ctx, token)
@@ -66,7 +74,12 @@ func (a *App) Refresh(token *oauth2.Token) (*oauth2.Token, error) {
 
 // NewClient creates a new Asana client using the provided credentials
 func (a *App) NewClient(token *oauth2.Token) *Client {
-	ctx := context.Background()
+	return a.NewClientContext(context.Background(), token)
+}
+
+// NewClientContext is like NewClient, but token refreshes made by the client
+// use the HTTP client carried by ctx as described in the oauth2 package
+func (a *App) NewClientContext(ctx context.Context, token *oauth2.Token) *Client {
 	client := a.config.Client(ctx, token)
 	return NewClient(client)
 }
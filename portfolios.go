@@ -1,24 +1,324 @@
 package asana
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
+// PortfolioBase contains the modifiable fields for a Portfolio
+type PortfolioBase struct {
+	// Name of the portfolio.
+	Name string `json:"name,omitempty"`
+
+	// Color of the portfolio. Must be one of the same values as a project
+	// color, such as dark-pink or light-green, or none.
+	Color string `json:"color,omitempty"`
+
+	// True if the portfolio is public to its workspace members.
+	Public *bool `json:"public,omitempty"`
+
+	// The day on which work for this portfolio begins, or null if the
+	// portfolio has no start date.
+	StartOn *Date `json:"start_on,omitempty"`
+
+	// The day on which this portfolio is due, or null if the portfolio has no
+	// due date.
+	DueOn *Date `json:"due_on,omitempty"`
+}
+
+// Portfolio is a collection of projects and other portfolios, which can be
+// tracked together. Portfolios have owners and members, and custom fields
+// which are shown for every item in the portfolio.
 type Portfolio struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
+
+	PortfolioBase
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The user who created this portfolio.
+	CreatedBy *User `json:"created_by,omitempty"`
+
+	// The current owner of the portfolio.
+	Owner *User `json:"owner,omitempty"`
+
+	// Read-only. Array of users who are members of this portfolio.
+	//
+	// Use AddMembers and RemoveMembers to change the members.
+	Members []*User `json:"members,omitempty"`
+
+	// Create-only. The workspace or organization this object is associated
+	// with.
+	Workspace *Workspace `json:"workspace,omitempty"`
+
+	// Read-only. Array of Custom Field Settings (in compact form), which
+	// apply to the items in this portfolio.
+	CustomFieldSettings []*CustomFieldSetting `json:"custom_field_settings,omitempty"`
+
+	// Array of custom field values set on the portfolio for a custom field
+	// applied to a parent portfolio.
+	CustomFields []*CustomFieldValue `json:"custom_fields,omitempty"`
+
+	// Read-only. The latest status update posted to this portfolio.
+	CurrentStatusUpdate *Resource `json:"current_status_update,omitempty"`
+
+	// Read-only. A URL to the portfolio in the Asana web application.
+	PermalinkURL string `json:"permalink_url,omitempty"`
+}
+
+// PortfolioItem is a project or a nested portfolio within a portfolio
+type PortfolioItem struct {
+	Project
+
+	// Read-only. Either "project" or "portfolio".
+	ResourceType string `json:"resource_type,omitempty"`
+}
+
+// GetID returns the ID of the portfolio
+func (p *Portfolio) GetID() string {
+	return p.ID
+}
+
+// Fetch loads the full details for this Portfolio
+func (p *Portfolio) Fetch(client *Client, opts ...*Options) error {
+	return p.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (p *Portfolio) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading portfolio details for %q", p.Name)
+
+	_, err := client.get(ctx, fmt.Sprintf("/portfolios/%s", p.ID), nil, p, opts...)
+	return err
 }
 
-// Projects returns a list of projects in this workspace
+// Portfolios returns a list of portfolios in this workspace. The API only
+// lists portfolios owned by a single user, which is the authenticated user
+// unless another is given with Options.Owner.
 func (w *Workspace) Portfolios(client *Client, options ...*Options) ([]*Portfolio, *NextPage, error) {
+	return w.PortfoliosContext(context.Background(), client, options...)
+}
+
+// PortfoliosContext is like Portfolios but uses ctx for the API request
+func (w *Workspace) PortfoliosContext(ctx context.Context, client *Client, options ...*Options) ([]*Portfolio, *NextPage, error) {
 	client.trace("Listing portfolios in %q", w.Name)
 
 	var result []*Portfolio
 
-	o := &Options{
+	// Options given by the caller override these defaults
+	query := &Options{
 		Workspace: w.ID,
 		Owner:     "me",
 	}
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/portfolios"), nil, &result, append(options, o)...)
+	nextPage, err := client.get(ctx, "/portfolios", query, &result, options...)
+	return result, nextPage, err
+}
+
+// IteratePortfolios returns an Iterator over the portfolios in this workspace
+func (w *Workspace) IteratePortfolios(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Portfolio] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Portfolio, *NextPage, error) {
+		return w.PortfoliosContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// CreatePortfolioRequest represents a request to create a portfolio
+type CreatePortfolioRequest struct {
+	PortfolioBase
+
+	// Required: The workspace or organization to create the portfolio in.
+	Workspace string `json:"workspace"`
+
+	// Users to add as members of the portfolio, in addition to the owner.
+	Members []string `json:"members,omitempty"`
+}
+
+// CreatePortfolio creates a new portfolio, owned by the authenticated user
+func (c *Client) CreatePortfolio(request *CreatePortfolioRequest) (*Portfolio, error) {
+	return c.CreatePortfolioContext(context.Background(), request)
+}
+
+// CreatePortfolioContext is like CreatePortfolio but uses ctx for the API request
+func (c *Client) CreatePortfolioContext(ctx context.Context, request *CreatePortfolioRequest) (*Portfolio, error) {
+	c.info("Creating portfolio %q\n", request.Name)
+
+	result := &Portfolio{}
+	err := c.post(ctx, "/portfolios", request, result)
+	return result, err
+}
+
+// Update applies new values to a Portfolio record
+func (p *Portfolio) Update(client *Client, update *PortfolioBase, opts ...*Options) error {
+	return p.UpdateContext(context.Background(), client, update, opts...)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (p *Portfolio) UpdateContext(ctx context.Context, client *Client, update *PortfolioBase, opts ...*Options) error {
+	client.trace("Update portfolio %q", p.Name)
+
+	return client.put(ctx, fmt.Sprintf("/portfolios/%s", p.ID), update, p, opts...)
+}
+
+// Delete removes this portfolio. The items in the portfolio are not deleted.
+func (p *Portfolio) Delete(client *Client) error {
+	return p.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (p *Portfolio) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting portfolio %q", p.Name)
+
+	return client.delete(ctx, fmt.Sprintf("/portfolios/%s", p.ID))
+}
+
+// Items returns a list of the projects and portfolios in this portfolio
+func (p *Portfolio) Items(client *Client, opts ...*Options) ([]*PortfolioItem, *NextPage, error) {
+	return p.ItemsContext(context.Background(), client, opts...)
+}
+
+// ItemsContext is like Items but uses ctx for the API request
+func (p *Portfolio) ItemsContext(ctx context.Context, client *Client, opts ...*Options) ([]*PortfolioItem, *NextPage, error) {
+	client.trace("Listing items in portfolio %q", p.Name)
+	var result []*PortfolioItem
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/portfolios/%s/items", p.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateItems returns an Iterator over the items in this portfolio
+func (p *Portfolio) IterateItems(ctx context.Context, client *Client, opts ...*Options) *Iterator[*PortfolioItem] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*PortfolioItem, *NextPage, error) {
+		return p.ItemsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// AddPortfolioItemRequest defines the item to add to a portfolio and its
+// position
+type AddPortfolioItemRequest struct {
+	Item         string // Required: The project or portfolio to add.
+	InsertBefore string // An item in the portfolio to insert the new item before.
+	InsertAfter  string // An item in the portfolio to insert the new item after.
+}
+
+// AddItem adds a project or portfolio to this portfolio
+func (p *Portfolio) AddItem(client *Client, request *AddPortfolioItemRequest) error {
+	return p.AddItemContext(context.Background(), client, request)
+}
+
+// AddItemContext is like AddItem but uses ctx for the API request
+func (p *Portfolio) AddItemContext(ctx context.Context, client *Client, request *AddPortfolioItemRequest) error {
+	client.trace("Adding item %q to portfolio %q", request.Item, p.ID)
+
+	m := map[string]interface{}{
+		"item": request.Item,
+	}
+	if request.InsertBefore != "" {
+		m["insert_before"] = request.InsertBefore
+	}
+	if request.InsertAfter != "" {
+		m["insert_after"] = request.InsertAfter
+	}
+	return client.post(ctx, fmt.Sprintf("/portfolios/%s/addItem", p.ID), m, nil)
+}
+
+// RemoveItem removes a project or portfolio from this portfolio
+func (p *Portfolio) RemoveItem(client *Client, itemID string) error {
+	return p.RemoveItemContext(context.Background(), client, itemID)
+}
+
+// RemoveItemContext is like RemoveItem but uses ctx for the API request
+func (p *Portfolio) RemoveItemContext(ctx context.Context, client *Client, itemID string) error {
+	client.trace("Removing item %q from portfolio %q", itemID, p.ID)
+
+	return client.post(ctx, fmt.Sprintf("/portfolios/%s/removeItem", p.ID), map[string]interface{}{
+		"item": itemID,
+	}, nil)
+}
+
+// AddMembers adds users as members of this portfolio, and returns the
+// updated portfolio
+func (p *Portfolio) AddMembers(client *Client, members []string, opts ...*Options) (*Portfolio, error) {
+	return p.AddMembersContext(context.Background(), client, members, opts...)
+}
+
+// AddMembersContext is like AddMembers but uses ctx for the API request
+func (p *Portfolio) AddMembersContext(ctx context.Context, client *Client, members []string, opts ...*Options) (*Portfolio, error) {
+	client.trace("Adding members to portfolio %q", p.ID)
+
+	result := &Portfolio{}
+	err := client.post(ctx, fmt.Sprintf("/portfolios/%s/addMembers", p.ID), membersRequest(members), result, opts...)
+	return result, err
+}
+
+// RemoveMembers removes users from the members of this portfolio, and
+// returns the updated portfolio
+func (p *Portfolio) RemoveMembers(client *Client, members []string, opts ...*Options) (*Portfolio, error) {
+	return p.RemoveMembersContext(context.Background(), client, members, opts...)
+}
+
+// RemoveMembersContext is like RemoveMembers but uses ctx for the API request
+func (p *Portfolio) RemoveMembersContext(ctx context.Context, client *Client, members []string, opts ...*Options) (*Portfolio, error) {
+	client.trace("Removing members from portfolio %q", p.ID)
+
+	result := &Portfolio{}
+	err := client.post(ctx, fmt.Sprintf("/portfolios/%s/removeMembers", p.ID), membersRequest(members), result, opts...)
+	return result, err
+}
+
+// ListCustomFieldSettings returns the custom fields applied to the items in
+// this portfolio
+func (p *Portfolio) ListCustomFieldSettings(client *Client, opts ...*Options) ([]*CustomFieldSetting, *NextPage, error) {
+	return p.ListCustomFieldSettingsContext(context.Background(), client, opts...)
+}
+
+// ListCustomFieldSettingsContext is like ListCustomFieldSettings but uses ctx for the API request
+func (p *Portfolio) ListCustomFieldSettingsContext(ctx context.Context, client *Client, opts ...*Options) ([]*CustomFieldSetting, *NextPage, error) {
+	client.trace("Listing custom field settings of portfolio %q", p.ID)
+	var result []*CustomFieldSetting
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/portfolios/%s/custom_field_settings", p.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
+
+// IterateCustomFieldSettings returns an Iterator over the custom fields
+// applied to the items in this portfolio
+func (p *Portfolio) IterateCustomFieldSettings(ctx context.Context, client *Client, opts ...*Options) *Iterator[*CustomFieldSetting] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*CustomFieldSetting, *NextPage, error) {
+		return p.ListCustomFieldSettingsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// AddCustomFieldSetting applies a custom field to the items in this
+// portfolio
+func (p *Portfolio) AddCustomFieldSetting(client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
+	return p.AddCustomFieldSettingContext(context.Background(), client, request)
+}
+
+// AddCustomFieldSettingContext is like AddCustomFieldSetting but uses ctx for the API request
+func (p *Portfolio) AddCustomFieldSettingContext(ctx context.Context, client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
+	client.trace("Attach custom field %q to portfolio %q", request.CustomField, p.ID)
+
+	result := &CustomFieldSetting{}
+	err := client.post(ctx, fmt.Sprintf("/portfolios/%s/addCustomFieldSetting", p.ID), request.encode(), result)
+	return result, err
+}
+
+// RemoveCustomFieldSetting removes a custom field from this portfolio
+func (p *Portfolio) RemoveCustomFieldSetting(client *Client, customFieldID string) error {
+	return p.RemoveCustomFieldSettingContext(context.Background(), client, customFieldID)
+}
+
+// RemoveCustomFieldSettingContext is like RemoveCustomFieldSetting but uses ctx for the API request
+func (p *Portfolio) RemoveCustomFieldSettingContext(ctx context.Context, client *Client, customFieldID string) error {
+	client.trace("Remove custom field %q from portfolio %q", customFieldID, p.ID)
+
+	return client.post(ctx, fmt.Sprintf("/portfolios/%s/removeCustomFieldSetting", p.ID), map[string]interface{}{
+		"custom_field": customFieldID,
+	}, nil)
+}
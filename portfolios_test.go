@@ -0,0 +1,127 @@
+package asana_test
+
+import (
+	"context"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestPortfolios(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+
+	portfolio, err := client.CreatePortfolio(&asana.CreatePortfolioRequest{
+		PortfolioBase: asana.PortfolioBase{Name: "Roadmap", Color: "light-green"},
+		Workspace:     workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if portfolio.Owner == nil || portfolio.Owner.ID != server.Me().ID {
+		t.Errorf("Unexpected owner %v", portfolio.Owner)
+	}
+
+	// Portfolios are listed for the current user unless another owner is given
+	portfolios, _, err := workspace.Portfolios(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(portfolios) != 1 || portfolios[0].ID != portfolio.ID {
+		t.Errorf("Unexpected portfolios %v", portfolios)
+	}
+	portfolios, _, err = workspace.Portfolios(client, &asana.Options{Owner: alice.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(portfolios) != 0 {
+		t.Errorf("Unexpected portfolios for %s: %v", alice.Name, portfolios)
+	}
+
+	var projects []string
+	for _, name := range []string{"Alpha", "Beta", "Gamma"} {
+		project, err := client.CreateProject(&asana.CreateProjectRequest{
+			ProjectBase: asana.ProjectBase{Name: name},
+			Workspace:   workspace.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		projects = append(projects, project.ID)
+	}
+	for _, id := range projects[1:] {
+		if err := portfolio.AddItem(client, &asana.AddPortfolioItemRequest{Item: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = portfolio.AddItem(client, &asana.AddPortfolioItemRequest{Item: projects[0], InsertBefore: projects[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := portfolio.IterateItems(context.Background(), client, &asana.Options{Limit: 2}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0].Name != "Alpha" || items[2].Name != "Gamma" || items[0].ResourceType != "project" {
+		t.Errorf("Unexpected items %v", items)
+	}
+	if err := portfolio.RemoveItem(client, projects[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := portfolio.AddMembers(client, []string{alice.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Members) != 2 {
+		t.Errorf("Unexpected members %v", userIDs(updated.Members))
+	}
+
+	field, err := client.CreateCustomField(&asana.CreateCustomFieldRequest{
+		CustomFieldBase: asana.CustomFieldBase{Name: "Budget", ResourceSubtype: asana.FieldTypeNumber},
+		Workspace:       workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := portfolio.AddCustomFieldSetting(client, &asana.AddCustomFieldSettingRequest{CustomField: field.ID}); err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := portfolio.ListCustomFieldSettings(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings) != 1 || settings[0].CustomField.ID != field.ID {
+		t.Errorf("Unexpected custom field settings %v", settings)
+	}
+	if err := portfolio.RemoveCustomFieldSetting(client, field.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := portfolio.Update(client, &asana.PortfolioBase{Name: "2024 Roadmap"}); err != nil {
+		t.Fatal(err)
+	}
+	fetched := &asana.Portfolio{ID: portfolio.ID}
+	if err := fetched.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Name != "2024 Roadmap" || len(fetched.Members) != 2 || len(fetched.CustomFieldSettings) != 0 {
+		t.Errorf("Unexpected portfolio %+v", fetched)
+	}
+	items, _, err = fetched.Items(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Errorf("Unexpected items %v", items)
+	}
+
+	if err := portfolio.Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := fetched.Fetch(client); !asana.IsNotFoundError(err) {
+		t.Errorf("Expected not found, but saw %v", err)
+	}
+}
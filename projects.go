@@ -1,8 +1,12 @@
 package asana
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // ProjectStatus is a description of the project’s status containing a color
@@ -44,6 +48,8 @@ type ProjectBase struct {
 
 	// A description of the project’s status containing a color (must be
 	// either null or one of: green, yellow, red) and a short description.
+	//
+	// Deprecated: use CurrentStatusUpdate and CreateStatusUpdate instead.
 	CurrentStatus *ProjectStatus `json:"current_status,omitempty"`
 
 	// The layout (board or list view) of the project.
@@ -151,6 +157,8 @@ type Project struct {
 	// subset of members who receive all notifications for a project, the
 	// default notification setting when adding members to a project in-
 	// product.
+	//
+	// Use AddFollowers and RemoveFollowers to change the followers.
 	Followers []*User `json:"followers,omitempty"`
 
 	// The current owner of the project, may be null.
@@ -159,6 +167,9 @@ type Project struct {
 	// Create-only. The team that this project is shared with. This field only
 	// exists for projects in organizations.
 	Team *Team `json:"team,omitempty"`
+
+	// Read-only. The latest status update posted to this project.
+	CurrentStatusUpdate *Resource `json:"current_status_update,omitempty"`
 }
 
 func (p *Project) GetID() string {
@@ -167,9 +178,14 @@ func (p *Project) GetID() string {
 
 // Fetch loads the full details for this Project
 func (p *Project) Fetch(client *Client, opts ...*Options) error {
+	return p.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (p *Project) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
 	client.trace("Loading project details for %q", p.Name)
 
-	_, err := client.get(fmt.Sprintf("/projects/%s", p.ID), nil, p, opts...)
+	_, err := client.get(ctx, fmt.Sprintf("/projects/%s", p.ID), nil, p, opts...)
 	return err
 }
 
@@ -180,23 +196,40 @@ func (p *Project) Fetch(client *Client, opts ...*Options) error {
 //
 // Updates the referenced project object
 func (p *Project) Update(client *Client, request *UpdateProjectRequest, opts ...*Options) error {
+	return p.UpdateContext(context.Background(), client, request, opts...)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (p *Project) UpdateContext(ctx context.Context, client *Client, request *UpdateProjectRequest, opts ...*Options) error {
 	client.trace("Update project %q", p.Name)
 
-	err := client.put(fmt.Sprintf("/projects/%s", p.ID), request, p, opts...)
+	err := client.put(ctx, fmt.Sprintf("/projects/%s", p.ID), request, p, opts...)
 	return err
 }
 
 // Projects returns a list of projects in this workspace
 func (w *Workspace) Projects(client *Client, options ...*Options) ([]*Project, *NextPage, error) {
+	return w.ProjectsContext(context.Background(), client, options...)
+}
+
+// ProjectsContext is like Projects but uses ctx for the API request
+func (w *Workspace) ProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, *NextPage, error) {
 	client.trace("Listing projects in %q", w.Name)
 
 	var result []*Project
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/workspaces/%s/projects", w.ID), nil, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/workspaces/%s/projects", w.ID), nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateProjects returns an Iterator over the projects in this workspace
+func (w *Workspace) IterateProjects(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Project] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Project, *NextPage, error) {
+		return w.ProjectsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 type favoritesRequestParams struct {
 	ResourceType string `url:"resource_type"`
 	Workspace    string `url:"workspace"`
@@ -204,6 +237,11 @@ type favoritesRequestParams struct {
 
 // FavoriteProjects returns a list of the current user's favorite projects in this workspace
 func (w *Workspace) FavoriteProjects(client *Client, options ...*Options) ([]*Project, *NextPage, error) {
+	return w.FavoriteProjectsContext(context.Background(), client, options...)
+}
+
+// FavoriteProjectsContext is like FavoriteProjects but uses ctx for the API requests
+func (w *Workspace) FavoriteProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, *NextPage, error) {
 	client.trace("Listing favorite projects in %q", w.Name)
 
 	var result []*Project
@@ -213,116 +251,201 @@ func (w *Workspace) FavoriteProjects(client *Client, options ...*Options) ([]*Pr
 		ResourceType: "project",
 		Workspace:    w.ID,
 	}
-	user, err := client.CurrentUser()
+	user, err := client.CurrentUserContext(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
-	nextPage, err := client.get(fmt.Sprintf("/users/%s/favorites", user.ID), query, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/users/%s/favorites", user.ID), query, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateFavoriteProjects returns an Iterator over the current user's favorite projects in this workspace
+func (w *Workspace) IterateFavoriteProjects(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Project] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Project, *NextPage, error) {
+		return w.FavoriteProjectsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllProjects repeatedly pages through all available projects in a workspace
 func (w *Workspace) AllProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = w.Projects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return w.AllProjectsContext(context.Background(), client, options...)
+}
 
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+// AllProjectsContext is like AllProjects but uses ctx for the API requests
+func (w *Workspace) AllProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, error) {
+	return w.IterateProjects(ctx, client, options...).All()
 }
 
 // AllProjects repeatedly pages through all available projects in a workspace
 func (w *Workspace) AllFavoriteProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = w.FavoriteProjects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return w.AllFavoriteProjectsContext(context.Background(), client, options...)
+}
 
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+// AllFavoriteProjectsContext is like AllFavoriteProjects but uses ctx for the API requests
+func (w *Workspace) AllFavoriteProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, error) {
+	return w.IterateFavoriteProjects(ctx, client, options...).All()
 }
 
 // Projects returns a list of projects in this team
 func (t *Team) Projects(client *Client, options ...*Options) ([]*Project, *NextPage, error) {
+	return t.ProjectsContext(context.Background(), client, options...)
+}
+
+// ProjectsContext is like Projects but uses ctx for the API request
+func (t *Team) ProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, *NextPage, error) {
 	client.trace("Listing projects in %q", t.Name)
 
 	var result []*Project
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/teams/%s/projects", t.ID), nil, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/teams/%s/projects", t.ID), nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateProjects returns an Iterator over the projects in this team
+func (t *Team) IterateProjects(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Project] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Project, *NextPage, error) {
+		return t.ProjectsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllProjects repeatedly pages through all available projects in a team
 func (t *Team) AllProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = t.Projects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return t.AllProjectsContext(context.Background(), client, options...)
+}
 
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+// AllProjectsContext is like AllProjects but uses ctx for the API requests
+func (t *Team) AllProjectsContext(ctx context.Context, client *Client, options ...*Options) ([]*Project, error) {
+	return t.IterateProjects(ctx, client, options...).All()
 }
 
 // CreateProject adds a new project to a workspace
 func (c *Client) CreateProject(project *CreateProjectRequest) (*Project, error) {
+	return c.CreateProjectContext(context.Background(), project)
+}
+
+// CreateProjectContext is like CreateProject but uses ctx for the API request
+func (c *Client) CreateProjectContext(ctx context.Context, project *CreateProjectRequest) (*Project, error) {
 	c.info("Creating project %q\n", project.Name)
 
 	result := &Project{}
 
-	err := c.post("/projects", project, result)
+	err := c.post(ctx, "/projects", project, result)
 	return result, err
 }
 
 // CreateProject adds a new project to a team
 func (t *Team) CreateProject(c *Client, project *CreateProjectRequest) (*Project, error) {
+	return t.CreateProjectContext(context.Background(), c, project)
+}
+
+// CreateProjectContext is like CreateProject but uses ctx for the API request
+func (t *Team) CreateProjectContext(ctx context.Context, c *Client, project *CreateProjectRequest) (*Project, error) {
 	c.info("Creating project %q\n", project.Name)
 
 	result := &Project{}
 
-	err := c.post(fmt.Sprintf("/teams/%s/projects", t.ID), project, result)
+	err := c.post(ctx, fmt.Sprintf("/teams/%s/projects", t.ID), project, result)
+	return result, err
+}
+
+// ProjectDuplicateOption selects optional parts of a project to copy when it
+// is duplicated
+type ProjectDuplicateOption string
+
+// Project duplication options
+const (
+	ProjectDuplicateMembers          ProjectDuplicateOption = "members"
+	ProjectDuplicateNotes            ProjectDuplicateOption = "notes"
+	ProjectDuplicateForms            ProjectDuplicateOption = "forms"
+	ProjectDuplicateTaskNotes        ProjectDuplicateOption = "task_notes"
+	ProjectDuplicateTaskAssignee     ProjectDuplicateOption = "task_assignee"
+	ProjectDuplicateTaskSubtasks     ProjectDuplicateOption = "task_subtasks"
+	ProjectDuplicateTaskAttachments  ProjectDuplicateOption = "task_attachments"
+	ProjectDuplicateTaskDates        ProjectDuplicateOption = "task_dates"
+	ProjectDuplicateTaskDependencies ProjectDuplicateOption = "task_dependencies"
+	ProjectDuplicateTaskFollowers    ProjectDuplicateOption = "task_followers"
+	ProjectDuplicateTaskTags         ProjectDuplicateOption = "task_tags"
+	ProjectDuplicateTaskProjects     ProjectDuplicateOption = "task_projects"
+)
+
+// ScheduleDates shifts the dates of a duplicated project's tasks so that the
+// project starts or ends on a given day. Exactly one of DueOn and StartOn
+// must be set.
+type ScheduleDates struct {
+	// Skip weekends when shifting dates.
+	ShouldSkipWeekends bool `json:"should_skip_weekends"`
+
+	// The last due date in the new project.
+	DueOn *Date `json:"due_on,omitempty"`
+
+	// The first start date in the new project.
+	StartOn *Date `json:"start_on,omitempty"`
+}
+
+// DuplicateProjectRequest defines the new project created by Duplicate
+type DuplicateProjectRequest struct {
+	// Required: The name of the new project.
+	Name string
+
+	// The team to share the new project with. Defaults to the team of the
+	// original project.
+	Team string
+
+	// The optional parts of the project to copy.
+	Include []ProjectDuplicateOption
+
+	// How to shift task dates, when ProjectDuplicateTaskDates is included.
+	ScheduleDates *ScheduleDates
+}
+
+// Validate checks the request has a name and valid schedule dates
+func (r *DuplicateProjectRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("Missing name for duplicated project")
+	}
+	if s := r.ScheduleDates; s != nil && (s.DueOn == nil) == (s.StartOn == nil) {
+		return errors.New("Exactly one of DueOn and StartOn must be set in ScheduleDates")
+	}
+	return nil
+}
+
+func (r *DuplicateProjectRequest) encode() map[string]interface{} {
+	// Custom request encoding
+	m := map[string]interface{}{
+		"name": r.Name,
+	}
+	if r.Team != "" {
+		m["team"] = r.Team
+	}
+	if len(r.Include) > 0 {
+		var include []string
+		for _, option := range r.Include {
+			include = append(include, string(option))
+		}
+		m["include"] = strings.Join(include, ",")
+	}
+	if r.ScheduleDates != nil {
+		m["schedule_dates"] = r.ScheduleDates
+	}
+	return m
+}
+
+// Duplicate starts a job copying this project. Use Job.Wait to wait for the
+// new project to be created.
+func (p *Project) Duplicate(client *Client, request *DuplicateProjectRequest) (*Job, error) {
+	return p.DuplicateContext(context.Background(), client, request)
+}
+
+// DuplicateContext is like Duplicate but uses ctx for the API request
+func (p *Project) DuplicateContext(ctx context.Context, client *Client, request *DuplicateProjectRequest) (*Job, error) {
+	client.info("Duplicating project %q as %q", p.ID, request.Name)
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &Job{}
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/duplicate", p.ID), request.encode(), result)
 	return result, err
 }
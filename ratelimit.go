@@ -0,0 +1,231 @@
+package asana
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit describes the request quotas Asana applies to each access token.
+// See https://developers.asana.com/docs/rate-limits
+type RateLimit struct {
+	// The number of requests allowed per minute
+	RequestsPerMinute int
+
+	// The number of requests which may be sent at once before waiting for
+	// the quota to refill. Defaults to a tenth of RequestsPerMinute.
+	Burst int
+
+	// The number of GET requests which may be in progress at the same time
+	MaxConcurrentReads int
+
+	// The number of POST, PUT, PATCH and DELETE requests which may be in
+	// progress at the same time
+	MaxConcurrentWrites int
+}
+
+// Standard rate limits for Asana plan tiers
+var (
+	FreeRateLimit = RateLimit{
+		RequestsPerMinute:   150,
+		MaxConcurrentReads:  50,
+		MaxConcurrentWrites: 15,
+	}
+	PaidRateLimit = RateLimit{
+		RequestsPerMinute:   1500,
+		MaxConcurrentReads:  50,
+		MaxConcurrentWrites: 15,
+	}
+)
+
+// RateLimiterStats reports how much a RateLimiter has delayed requests
+type RateLimiterStats struct {
+	// The number of requests which have passed through the limiter
+	Requests int64
+
+	// The number of requests which had to wait before being sent
+	Delayed int64
+
+	// The total and longest time requests spent waiting
+	TotalWait time.Duration
+	MaxWait   time.Duration
+
+	// The number of 429 Too Many Requests responses observed
+	RateLimited int64
+
+	// The number of requests per minute currently allowed, which is reduced
+	// after a 429 response and recovers as requests succeed
+	CurrentRate float64
+}
+
+// RateLimiter throttles the requests made by a Client to stay within the
+// API quotas. It combines a token bucket for the request rate with separate
+// limits on concurrent reads and writes.
+//
+// A single RateLimiter is safe for use by multiple goroutines, and should be
+// shared by all clients using the same access token.
+type RateLimiter struct {
+	limit  RateLimit
+	reads  chan struct{}
+	writes chan struct{}
+
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	stats       RateLimiterStats
+}
+
+// NewRateLimiter creates a RateLimiter enforcing the given quotas. Zero
+// values in limit disable the corresponding quota.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Burst <= 0 {
+		limit.Burst = limit.RequestsPerMinute / 10
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+	}
+
+	l := &RateLimiter{
+		limit:  limit,
+		rate:   float64(limit.RequestsPerMinute) / 60,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+	if limit.MaxConcurrentReads > 0 {
+		l.reads = make(chan struct{}, limit.MaxConcurrentReads)
+	}
+	if limit.MaxConcurrentWrites > 0 {
+		l.writes = make(chan struct{}, limit.MaxConcurrentWrites)
+	}
+	return l
+}
+
+// Stats returns a snapshot of the limiter's metrics
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := l.stats
+	stats.CurrentRate = l.rate * 60
+	return stats
+}
+
+// wait blocks until a request with the given method may be sent. The
+// returned function must be called once the response has been read.
+func (l *RateLimiter) wait(ctx context.Context, method string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+
+	// Wait for a concurrency slot
+	slots := l.writes
+	if method == http.MethodGet {
+		slots = l.reads
+	}
+	release := func() {}
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			release = func() { <-slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// Wait for a token
+	if err := sleep(ctx, l.reserve(start)); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		release()
+		return nil, err
+	}
+
+	l.record(time.Since(start))
+	return release, nil
+}
+
+// reserve takes a token from the bucket and returns how long to wait before
+// it may be used
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	// Refill the bucket
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if burst := float64(l.limit.Burst); l.tokens > burst {
+		l.tokens = burst
+	}
+	l.last = now
+
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if paused := l.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+	return wait
+}
+
+func (l *RateLimiter) record(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	if wait > time.Millisecond {
+		l.stats.Delayed++
+		l.stats.TotalWait += wait
+		if wait > l.stats.MaxWait {
+			l.stats.MaxWait = wait
+		}
+	}
+}
+
+// observeRateLimit pauses all requests for the Retry-After duration of a 429
+// response and halves the request rate
+func (l *RateLimiter) observeRateLimit(retryAfter time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.RateLimited++
+
+	if retryAfter <= 0 {
+		retryAfter = time.Second
+	}
+	if until := time.Now().Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+
+	minRate := float64(l.limit.RequestsPerMinute) / 60 / 16
+	if l.rate /= 2; l.rate < minRate {
+		l.rate = minRate
+	}
+	l.tokens = 0
+}
+
+// observeSuccess gradually restores the request rate after a 429 response
+func (l *RateLimiter) observeSuccess() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	maxRate := float64(l.limit.RequestsPerMinute) / 60
+	if l.rate += maxRate / 100; l.rate > maxRate {
+		l.rate = maxRate
+	}
+}
@@ -0,0 +1,73 @@
+package asana
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	l := NewRateLimiter(RateLimit{RequestsPerMinute: 600, Burst: 2})
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if wait := l.reserve(now); wait != 0 {
+			t.Errorf("Expected burst request %d not to wait, but saw %s", i, wait)
+		}
+	}
+	if wait := l.reserve(now); wait != 100*time.Millisecond {
+		t.Errorf("Expected to wait 100ms for a token, but saw %s", wait)
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	l := NewRateLimiter(RateLimit{MaxConcurrentReads: 1, MaxConcurrentWrites: 1})
+
+	release, err := l.wait(context.Background(), http.MethodPost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.wait(ctx, http.MethodPut); err != context.DeadlineExceeded {
+		t.Errorf("Expected a second write to wait for the first, but saw %v", err)
+	}
+	if readRelease, err := l.wait(context.Background(), http.MethodGet); err != nil {
+		t.Errorf("Expected a read not to wait for a write, but saw %v", err)
+	} else {
+		readRelease()
+	}
+
+	release()
+	if release, err := l.wait(context.Background(), http.MethodPut); err != nil {
+		t.Errorf("Expected a write after release not to wait, but saw %v", err)
+	} else {
+		release()
+	}
+
+	if stats := l.Stats(); stats.Requests != 3 {
+		t.Errorf("Expected 3 requests, but saw %d", stats.Requests)
+	}
+}
+
+func TestRateLimiterAdapts(t *testing.T) {
+	l := NewRateLimiter(PaidRateLimit)
+
+	l.observeRateLimit(2 * time.Second)
+	stats := l.Stats()
+	if stats.RateLimited != 1 || stats.CurrentRate != 750 {
+		t.Errorf("Expected the rate to halve after a 429, but saw %+v", stats)
+	}
+	if wait := l.reserve(time.Now()); wait < time.Second {
+		t.Errorf("Expected requests to pause for Retry-After, but saw %s", wait)
+	}
+
+	for i := 0; i < 100; i++ {
+		l.observeSuccess()
+	}
+	if rate := l.Stats().CurrentRate; rate != 1500 {
+		t.Errorf("Expected the rate to recover, but saw %v", rate)
+	}
+}
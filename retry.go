@@ -0,0 +1,96 @@
+package asana
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy describes how the client retries requests which fail with a
+// recoverable error: either a 5xx server error or a 429 rate limit response.
+//
+// Requests with a JSON body are replayed as-is. Attachment uploads are only
+// retried if the provided reader also implements io.Seeker, so that the
+// contents can be rewound.
+type RetryPolicy struct {
+	// The maximum number of attempts made for a request, including the
+	// first. Values less than 1 are treated as 1.
+	MaxAttempts int
+
+	// The delay before the first retry. Each further retry doubles the delay
+	// until MaxDelay is reached.
+	BaseDelay time.Duration
+
+	// The longest delay between two attempts. Zero means no limit.
+	MaxDelay time.Duration
+
+	// The fraction of each backoff delay, between 0 and 1, which is replaced
+	// by a random value so that concurrent clients do not retry in lockstep.
+	Jitter float64
+
+	// Rate limited requests wait for the duration in the Retry-After header
+	// instead of the backoff delay, unless this is set.
+	IgnoreRetryAfter bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most clients
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	return p != nil && (IsRateLimited(err) || IsRecoverableError(err))
+}
+
+// delay returns how long to wait after the given attempt failed with err
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	if e, ok := IsAsanaError(err); ok && e.StatusCode == 429 && e.RetryAfter > 0 && !p.IgnoreRetryAfter {
+		return e.RetryAfter
+	}
+
+	// Without a MaxDelay, doubling stops well before the delay would
+	// overflow, leaving room for the jitter calculation
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || d < p.MaxDelay) && d < math.MaxInt64/4; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := time.Duration(float64(d) * p.Jitter)
+		d = d - jitter + time.Duration(rand.Int63n(int64(jitter)+1))
+	}
+	return d
+}
+
+// sleep waits for the given duration, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,175 @@
+package asana
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for a server which fails with the given
+// status codes before succeeding, along with a pointer to the request count
+func newTestClient(t *testing.T, failures []int, check func(r *http.Request)) (*Client, *int) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			check(r)
+		}
+		count++
+		if count <= len(failures) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(failures[count-1])
+			fmt.Fprint(w, `{"errors":[{"message":"failed"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"gid":"1","name":"Task"}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+	client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	return client, &count
+}
+
+func TestRetryRecoverable(t *testing.T) {
+	client, count := newTestClient(t, []int{500, 429}, nil)
+
+	task := &Task{ID: "1"}
+	if err := task.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if *count != 3 {
+		t.Errorf("Expected 3 attempts, but saw %d", *count)
+	}
+	if task.Name != "Task" {
+		t.Errorf("Expected task to be loaded, but saw %q", task.Name)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	client, count := newTestClient(t, []int{503, 503, 503, 503}, nil)
+
+	err := (&Task{ID: "1"}).Fetch(client)
+	if !IsRecoverableError(err) {
+		t.Fatalf("Expected a recoverable error, but saw %v", err)
+	}
+	if *count != 3 {
+		t.Errorf("Expected 3 attempts, but saw %d", *count)
+	}
+}
+
+func TestRetryFatal(t *testing.T) {
+	client, count := newTestClient(t, []int{404}, nil)
+
+	if err := (&Task{ID: "1"}).Fetch(client); !IsNotFoundError(err) {
+		t.Fatalf("Expected a not found error, but saw %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("Expected 1 attempt, but saw %d", *count)
+	}
+}
+
+func TestRetryDelayManyAttempts(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 1000, BaseDelay: 2 * time.Second, Jitter: 0.5}
+
+	for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+		if d := policy.delay(attempt, nil); d <= 0 {
+			t.Fatalf("Expected a positive delay for attempt %d, but saw %v", attempt, d)
+		}
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var bodies []string
+	client, _ := newTestClient(t, []int{500}, func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	})
+
+	if _, err := client.CreateTask(&CreateTaskRequest{TaskBase: TaskBase{Name: "Task"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("Expected the same body to be sent twice, but saw %q", bodies)
+	}
+}
+
+type readSeekCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekCloser) Close() error {
+	return nil
+}
+
+func TestRetryMultipart(t *testing.T) {
+	var bodies []string
+	client, _ := newTestClient(t, []int{500}, func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	})
+
+	_, err := (&Task{ID: "1"}).CreateAttachment(client, &NewAttachment{
+		Reader:      readSeekCloser{bytes.NewReader([]byte("file contents"))},
+		FileName:    "file.txt",
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || !bytes.Contains([]byte(bodies[1]), []byte("file contents")) {
+		t.Errorf("Expected the file to be sent twice, but saw %q", bodies)
+	}
+}
+
+func TestRetryMultipartNotSeekable(t *testing.T) {
+	client, count := newTestClient(t, []int{500}, nil)
+	client.Retry.BaseDelay = time.Minute
+
+	start := time.Now()
+	_, err := (&Task{ID: "1"}).CreateAttachment(client, &NewAttachment{
+		Reader:      ioutil.NopCloser(io.LimitReader(bytes.NewReader([]byte("file contents")), 100)),
+		FileName:    "file.txt",
+		ContentType: "text/plain",
+	})
+	if !IsRecoverableError(err) {
+		t.Fatalf("Expected a recoverable error, but saw %v", err)
+	}
+	if *count != 1 {
+		t.Errorf("Expected 1 attempt, but saw %d", *count)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected to give up without waiting to retry, but saw %v", elapsed)
+	}
+}
+
+func TestRetryMultipartProgress(t *testing.T) {
+	client, _ := newTestClient(t, []int{500}, func(r *http.Request) {
+		ioutil.ReadAll(r.Body)
+	})
+
+	var progress []int64
+	_, err := (&Task{ID: "1"}).CreateAttachment(client, &NewAttachment{
+		Reader:      readSeekCloser{bytes.NewReader([]byte("file contents"))},
+		FileName:    "file.txt",
+		ContentType: "text/plain",
+		Progress: func(transferred, total int64) {
+			if total != 13 {
+				t.Errorf("Expected a total of 13 bytes, but saw %d", total)
+			}
+			progress = append(progress, transferred)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 2 || progress[0] != 13 || progress[1] != 13 {
+		t.Errorf("Expected progress to restart with the retry, but saw %v", progress)
+	}
+}
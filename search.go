@@ -0,0 +1,355 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SearchSortField is a field by which task search results can be ordered
+type SearchSortField string
+
+// Values for TaskSearch.SortBy
+const (
+	SortByModifiedAt  SearchSortField = "modified_at"
+	SortByCreatedAt   SearchSortField = "created_at"
+	SortByCompletedAt SearchSortField = "completed_at"
+	SortByDueDate     SearchSortField = "due_date"
+	SortByLikes       SearchSortField = "likes"
+)
+
+// CustomFieldPredicate is a comparison applied to the value of a custom field
+// in a task search
+type CustomFieldPredicate string
+
+// Predicates for CustomFieldFilter. Value and IsSet apply to all custom field
+// types; the text predicates apply to text fields and the comparisons to
+// number fields.
+const (
+	PredicateValue       CustomFieldPredicate = "value"
+	PredicateIsSet       CustomFieldPredicate = "is_set"
+	PredicateStartsWith  CustomFieldPredicate = "starts_with"
+	PredicateEndsWith    CustomFieldPredicate = "ends_with"
+	PredicateContains    CustomFieldPredicate = "contains"
+	PredicateLessThan    CustomFieldPredicate = "less_than"
+	PredicateGreaterThan CustomFieldPredicate = "greater_than"
+)
+
+// CustomFieldFilter matches tasks by the value of a custom field
+type CustomFieldFilter struct {
+	// Required: The ID of the custom field
+	ID string
+
+	// Required: The comparison to make
+	Predicate CustomFieldPredicate
+
+	// The value to compare with. For enum fields this is the ID of the enum
+	// option, and for IsSet a bool.
+	Value interface{}
+}
+
+// CustomFieldFilters encodes a set of custom field predicates as the
+// custom_fields.<gid>.<predicate> search parameters
+type CustomFieldFilters []*CustomFieldFilter
+
+// EncodeValues implements the query.Encoder interface
+func (f CustomFieldFilters) EncodeValues(key string, v *url.Values) error {
+	for _, filter := range f {
+		v.Add(fmt.Sprintf("%s.%s.%s", key, filter.ID, filter.Predicate), formatSearchValue(filter.Value))
+	}
+	return nil
+}
+
+func formatSearchValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case *Date:
+		return time.Time(*v).Format(dateLayout)
+	case Date:
+		return time.Time(v).Format(dateLayout)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// TaskSearch specifies which tasks to return from Workspace.SearchTasks. All
+// of the filters provided must match. Fields ending in Any match tasks with
+// any of the given values, Not excludes tasks with any of the given values,
+// and All requires every value to match.
+type TaskSearch struct {
+	// Performs full-text search on both task name and description
+	Text string `url:"text,omitempty"`
+
+	// Filters results by the task's resource subtype, such as milestone
+	ResourceSubtype string `url:"resource_subtype,omitempty"`
+
+	// Filters by user ID, or "me"
+	AssigneeAny []string `url:"assignee.any,omitempty,comma"`
+	AssigneeNot []string `url:"assignee.not,omitempty,comma"`
+
+	PortfoliosAny []string `url:"portfolios.any,omitempty,comma"`
+
+	ProjectsAny []string `url:"projects.any,omitempty,comma"`
+	ProjectsNot []string `url:"projects.not,omitempty,comma"`
+	ProjectsAll []string `url:"projects.all,omitempty,comma"`
+
+	SectionsAny []string `url:"sections.any,omitempty,comma"`
+	SectionsNot []string `url:"sections.not,omitempty,comma"`
+	SectionsAll []string `url:"sections.all,omitempty,comma"`
+
+	TagsAny []string `url:"tags.any,omitempty,comma"`
+	TagsNot []string `url:"tags.not,omitempty,comma"`
+	TagsAll []string `url:"tags.all,omitempty,comma"`
+
+	TeamsAny []string `url:"teams.any,omitempty,comma"`
+
+	FollowersNot []string `url:"followers.not,omitempty,comma"`
+
+	CreatedByAny []string `url:"created_by.any,omitempty,comma"`
+	CreatedByNot []string `url:"created_by.not,omitempty,comma"`
+
+	AssignedByAny []string `url:"assigned_by.any,omitempty,comma"`
+	AssignedByNot []string `url:"assigned_by.not,omitempty,comma"`
+
+	LikedByNot       []string `url:"liked_by.not,omitempty,comma"`
+	CommentedOnByNot []string `url:"commented_on_by.not,omitempty,comma"`
+
+	// Date ranges. The On, Before and After dates are compared with the
+	// date of the field in the workspace's time zone, while the At times
+	// are compared with the exact timestamp.
+	DueOn         *Date      `url:"due_on,omitempty"`
+	DueOnBefore   *Date      `url:"due_on.before,omitempty"`
+	DueOnAfter    *Date      `url:"due_on.after,omitempty"`
+	DueAtBefore   *time.Time `url:"due_at.before,omitempty"`
+	DueAtAfter    *time.Time `url:"due_at.after,omitempty"`
+	StartOn       *Date      `url:"start_on,omitempty"`
+	StartOnBefore *Date      `url:"start_on.before,omitempty"`
+	StartOnAfter  *Date      `url:"start_on.after,omitempty"`
+
+	CreatedOn       *Date      `url:"created_on,omitempty"`
+	CreatedOnBefore *Date      `url:"created_on.before,omitempty"`
+	CreatedOnAfter  *Date      `url:"created_on.after,omitempty"`
+	CreatedAtBefore *time.Time `url:"created_at.before,omitempty"`
+	CreatedAtAfter  *time.Time `url:"created_at.after,omitempty"`
+
+	CompletedOn       *Date      `url:"completed_on,omitempty"`
+	CompletedOnBefore *Date      `url:"completed_on.before,omitempty"`
+	CompletedOnAfter  *Date      `url:"completed_on.after,omitempty"`
+	CompletedAtBefore *time.Time `url:"completed_at.before,omitempty"`
+	CompletedAtAfter  *time.Time `url:"completed_at.after,omitempty"`
+
+	ModifiedOn       *Date      `url:"modified_on,omitempty"`
+	ModifiedOnBefore *Date      `url:"modified_on.before,omitempty"`
+	ModifiedOnAfter  *Date      `url:"modified_on.after,omitempty"`
+	ModifiedAtBefore *time.Time `url:"modified_at.before,omitempty"`
+	ModifiedAtAfter  *time.Time `url:"modified_at.after,omitempty"`
+
+	// Filters by task state
+	Completed     *bool `url:"completed,omitempty"`
+	IsBlocking    *bool `url:"is_blocking,omitempty"`
+	IsBlocked     *bool `url:"is_blocked,omitempty"`
+	HasAttachment *bool `url:"has_attachment,omitempty"`
+	IsSubtask     *bool `url:"is_subtask,omitempty"`
+
+	// Filters by custom field values
+	CustomFields CustomFieldFilters `url:"custom_fields,omitempty"`
+
+	// The field to sort results by. Defaults to SortByModifiedAt.
+	SortBy SearchSortField `url:"sort_by,omitempty"`
+
+	// Sort results in ascending order. Defaults to false.
+	SortAscending *bool `url:"sort_ascending,omitempty"`
+}
+
+// CustomField adds a custom field predicate to the search and returns the
+// search, so that calls can be chained:
+//
+//	search := (&asana.TaskSearch{Text: "release"}).
+//		CustomField(priorityID, asana.PredicateValue, highID).
+//		CustomField(estimateID, asana.PredicateLessThan, 5)
+func (s *TaskSearch) CustomField(id string, predicate CustomFieldPredicate, value interface{}) *TaskSearch {
+	s.CustomFields = append(s.CustomFields, &CustomFieldFilter{
+		ID:        id,
+		Predicate: predicate,
+		Value:     value,
+	})
+	return s
+}
+
+// Validate checks the custom field predicates are complete
+func (s *TaskSearch) Validate() error {
+	for _, filter := range s.CustomFields {
+		if filter.ID == "" {
+			return errors.New("Missing custom field ID in task search")
+		}
+		switch filter.Predicate {
+		case PredicateValue, PredicateStartsWith, PredicateEndsWith,
+			PredicateContains, PredicateLessThan, PredicateGreaterThan:
+		case PredicateIsSet:
+			if _, ok := filter.Value.(bool); !ok {
+				return errors.Errorf("The %s predicate for custom field %s requires a bool value", filter.Predicate, filter.ID)
+			}
+		default:
+			return errors.Errorf("Unknown predicate %q for custom field %s", filter.Predicate, filter.ID)
+		}
+	}
+	return nil
+}
+
+// SearchTasks returns the tasks in this workspace matching query. Search is
+// only available in premium workspaces.
+//
+// The search endpoint does not support pagination, and returns at most 100
+// tasks, as limited by Options.Limit. Use IterateSearchTasks to load all
+// matching tasks.
+func (w *Workspace) SearchTasks(client *Client, query *TaskSearch, opts ...*Options) ([]*Task, error) {
+	return w.SearchTasksContext(context.Background(), client, query, opts...)
+}
+
+// SearchTasksContext is like SearchTasks but uses ctx for the API request
+func (w *Workspace) SearchTasksContext(ctx context.Context, client *Client, query *TaskSearch, opts ...*Options) ([]*Task, error) {
+	return w.searchTasks(ctx, client, query, opts...)
+}
+
+func (w *Workspace) searchTasks(ctx context.Context, client *Client, query interface{}, opts ...*Options) ([]*Task, error) {
+	client.trace("Searching tasks in workspace %s", w.ID)
+
+	var result []*Task
+
+	// Make the request
+	_, err := client.get(ctx, fmt.Sprintf("/workspaces/%s/tasks/search", w.ID), query, &result, opts...)
+	return result, err
+}
+
+// searchCursor continues a search from the creation time of the last task
+// on the previous page
+type searchCursor struct {
+	*TaskSearch
+	CreatedAtBefore string `url:"created_at.before,omitempty"`
+}
+
+// The precision of created_at timestamps in the API
+const searchCursorLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// The largest number of results the search endpoint returns
+const maxSearchLimit = 100
+
+// IterateSearchTasks returns an Iterator over all tasks in this workspace
+// matching query.
+//
+// As the search endpoint does not return a next page, each page is
+// requested with a created_at.before filter set to the creation time of the
+// last task on the previous page. Results are therefore always sorted by
+// creation time, newest first, regardless of the SortBy and SortAscending
+// fields of query. The created_at field is added to the requested fields if
+// necessary.
+//
+// Tasks created in the same millisecond are returned together, using pages
+// larger than PageSize if necessary. If more than 100 matching tasks were
+// created in the same millisecond, which the cursor cannot divide, the
+// iterator stops with an error rather than skip any of them.
+func (w *Workspace) IterateSearchTasks(ctx context.Context, client *Client, query *TaskSearch, opts ...*Options) *Iterator[*Task] {
+	search := *query
+	search.SortBy = SortByCreatedAt
+	search.SortAscending = Bool(false)
+
+	opts = append(opts, &Options{Fields: searchFields(client, opts)})
+
+	// Tasks at the boundary of the previous page. The cursor includes the
+	// boundary time, as several tasks may be created in the same
+	// millisecond, so these are skipped when seen again.
+	var boundary time.Time
+	seen := make(map[string]bool)
+
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		cursor := &searchCursor{TaskSearch: &search}
+		if page.Offset != "" {
+			next := search
+			next.CreatedAtBefore = nil
+			cursor = &searchCursor{TaskSearch: &next, CreatedAtBefore: page.Offset}
+		}
+
+		// If the whole page was created at the boundary time and has been
+		// seen already, ask for a larger page to get past it
+		var tasks, result []*Task
+		for limit := page.Limit; ; limit *= 2 {
+			if limit > maxSearchLimit {
+				limit = maxSearchLimit
+			}
+			var err error
+			tasks, err = w.searchTasks(ctx, client, cursor, append([]*Options{{Limit: limit}}, opts...)...)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			result = nil
+			for _, task := range tasks {
+				if !seen[task.ID] {
+					result = append(result, task)
+				}
+			}
+			if len(tasks) < limit {
+				return result, nil, nil
+			}
+			if len(result) > 0 {
+				break
+			}
+			if limit == maxSearchLimit {
+				return nil, nil, errors.Errorf("More than %d tasks matching the search were created at %s, so they cannot all be listed",
+					maxSearchLimit, boundary.UTC().Format(searchCursorLayout))
+			}
+		}
+
+		last := tasks[len(tasks)-1]
+		if last.CreatedAt == nil {
+			return nil, nil, errors.New("Task search results do not include created_at")
+		}
+
+		if !last.CreatedAt.Equal(boundary) {
+			boundary = *last.CreatedAt
+			seen = make(map[string]bool)
+		}
+		for _, task := range tasks {
+			if task.CreatedAt != nil && task.CreatedAt.Equal(boundary) {
+				seen[task.ID] = true
+			}
+		}
+		next := boundary.Add(time.Millisecond).UTC().Format(searchCursorLayout)
+		return result, &NextPage{Offset: next}, nil
+	})
+}
+
+// searchFields returns the fields to request from the search endpoint,
+// adding created_at to the fields requested in opts
+func searchFields(client *Client, opts []*Options) []string {
+	fields := client.DefaultOptions.Fields
+	for _, options := range opts {
+		if options != nil && len(options.Fields) > 0 {
+			fields = options.Fields
+		}
+	}
+	if len(fields) == 0 {
+		// The compact task representation
+		fields = []string{"name", "resource_subtype"}
+	}
+
+	for _, field := range fields {
+		if field == "created_at" {
+			return fields
+		}
+	}
+	return append(append([]string{}, fields...), "created_at")
+}
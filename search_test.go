@@ -0,0 +1,165 @@
+package asana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestTaskSearchEncoding(t *testing.T) {
+	due := Date(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC))
+	search := (&TaskSearch{
+		Text:        "release",
+		AssigneeAny: []string{"me", "123"},
+		ProjectsAll: []string{"1", "2"},
+		DueOnBefore: &due,
+		Completed:   Bool(false),
+		SortBy:      SortByDueDate,
+	}).
+		CustomField("42", PredicateValue, "enum1").
+		CustomField("43", PredicateLessThan, 2.5).
+		CustomField("44", PredicateIsSet, true)
+
+	if err := search.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := query.Values(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := url.Values{
+		"text":                       {"release"},
+		"assignee.any":               {"me,123"},
+		"projects.all":               {"1,2"},
+		"due_on.before":              {"2020-03-01"},
+		"completed":                  {"false"},
+		"sort_by":                    {"due_date"},
+		"custom_fields.42.value":     {"enum1"},
+		"custom_fields.43.less_than": {"2.5"},
+		"custom_fields.44.is_set":    {"true"},
+	}
+	if values.Encode() != expected.Encode() {
+		t.Errorf("Expected query\n%s\nbut saw\n%s", expected.Encode(), values.Encode())
+	}
+
+	invalid := (&TaskSearch{}).CustomField("42", PredicateIsSet, "yes")
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected is_set with a string value to be rejected")
+	}
+}
+
+type searchRecord struct {
+	id      string
+	created time.Time
+}
+
+// searchRecords returns n records with perMillisecond of them created in
+// each millisecond, newest first
+func searchRecords(n, perMillisecond int) []searchRecord {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var records []searchRecord
+	for i := 0; i < n; i++ {
+		records = append(records, searchRecord{
+			id:      strconv.Itoa(i),
+			created: base.Add(time.Duration(i/perMillisecond) * time.Millisecond),
+		})
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].created.After(records[j].created)
+	})
+	return records
+}
+
+// searchTasks iterates through a search over records with the given page
+// size, returning the tasks and the number of requests made
+func searchTasks(t *testing.T, records []searchRecord, pageSize int) ([]*Task, int, error) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		if q.Get("sort_by") != "created_at" || q.Get("sort_ascending") != "false" {
+			t.Errorf("Expected results sorted by creation time, but saw %s", r.URL.RawQuery)
+		}
+		if q.Get("opt_fields") != "name,created_at" {
+			t.Errorf("Expected created_at to be requested, but saw %q", q.Get("opt_fields"))
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit > 100 {
+			t.Errorf("Expected a limit of at most 100, but saw %d", limit)
+		}
+
+		var before time.Time
+		if cursor := q.Get("created_at.before"); cursor != "" {
+			before, _ = time.Parse(time.RFC3339Nano, cursor)
+		}
+
+		var data []map[string]interface{}
+		for _, record := range records {
+			if len(data) == limit {
+				break
+			}
+			if before.IsZero() || record.created.Before(before) {
+				data = append(data, map[string]interface{}{"gid": record.id, "created_at": record.created})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	workspace := &Workspace{ID: "1"}
+	it := workspace.IterateSearchTasks(context.Background(), client, &TaskSearch{Text: "x"}, &Options{Fields: []string{"name"}})
+	it.PageSize = pageSize
+	tasks, err := it.All()
+	return tasks, requests, err
+}
+
+func TestIterateSearchTasks(t *testing.T) {
+	for _, test := range []struct {
+		n, perMillisecond, pageSize int
+	}{
+		{250, 3, 50},
+		{20, 3, 2},
+		{120, 60, 10},
+	} {
+		// Several tasks created in the same millisecond
+		records := searchRecords(test.n, test.perMillisecond)
+		tasks, requests, err := searchTasks(t, records, test.pageSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(tasks) != len(records) {
+			t.Fatalf("Expected %d tasks, but saw %d after %d requests", len(records), len(tasks), requests)
+		}
+		seen := make(map[string]bool)
+		for i, task := range tasks {
+			if seen[task.ID] {
+				t.Errorf("Task %s returned more than once", task.ID)
+			}
+			seen[task.ID] = true
+			if task.ID != records[i].id {
+				t.Errorf("Expected task %d to be %s, but saw %s", i, records[i].id, task.ID)
+			}
+		}
+	}
+}
+
+func TestIterateSearchTasksTooManyAtOnce(t *testing.T) {
+	_, _, err := searchTasks(t, searchRecords(150, 150), 50)
+	if err == nil {
+		t.Error("Expected an error for more than 100 tasks created in one millisecond")
+	}
+}
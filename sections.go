@@ -1,8 +1,12 @@
 package asana
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 type SectionBase struct {
@@ -33,36 +37,63 @@ type Section struct {
 
 // Fetch loads the full details for this Section
 func (s *Section) Fetch(client *Client) error {
+	return s.FetchContext(context.Background(), client)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (s *Section) FetchContext(ctx context.Context, client *Client) error {
 	client.trace("Loading section details for %q", s.Name)
 
-	_, err := client.get(fmt.Sprintf("/sections/%s", s.ID), nil, s)
+	_, err := client.get(ctx, fmt.Sprintf("/sections/%s", s.ID), nil, s)
 	return err
 }
 
 func (s *Section) Delete(client *Client) error {
+	return s.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (s *Section) DeleteContext(ctx context.Context, client *Client) error {
 	client.trace("Delete section %s %q", s.ID, s.Name)
 
-	err := client.delete(fmt.Sprintf("/sections/%s", s.ID))
+	err := client.delete(ctx, fmt.Sprintf("/sections/%s", s.ID))
 	return err
 }
 
 // Sections returns a list of sections in this project
 func (p *Project) Sections(client *Client, opts ...*Options) ([]*Section, *NextPage, error) {
+	return p.SectionsContext(context.Background(), client, opts...)
+}
+
+// SectionsContext is like Sections but uses ctx for the API request
+func (p *Project) SectionsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Section, *NextPage, error) {
 	client.trace("Listing sections in %q", p.Name)
 	var result []*Section
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/projects/%s/sections", p.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/projects/%s/sections", p.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateSections returns an Iterator over the sections in this project
+func (p *Project) IterateSections(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Section] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Section, *NextPage, error) {
+		return p.SectionsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // CreateSection creates a new section in the given project
 func (p *Project) CreateSection(client *Client, section *SectionBase) (*Section, error) {
+	return p.CreateSectionContext(context.Background(), client, section)
+}
+
+// CreateSectionContext is like CreateSection but uses ctx for the API request
+func (p *Project) CreateSectionContext(ctx context.Context, client *Client, section *SectionBase) (*Section, error) {
 	client.info("Creating section %q", section.Name)
 
 	result := &Section{}
 
-	err := client.post(fmt.Sprintf("/projects/%s/sections", p.ID), section, result)
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/sections", p.ID), section, result)
 	return result, err
 }
 
@@ -80,9 +111,14 @@ type SectionInsertRequest struct {
 //
 // At this point in time, moving sections is not supported in list views, only board views.
 func (p *Project) InsertSection(client *Client, request *SectionInsertRequest) error {
+	return p.InsertSectionContext(context.Background(), client, request)
+}
+
+// InsertSectionContext is like InsertSection but uses ctx for the API request
+func (p *Project) InsertSectionContext(ctx context.Context, client *Client, request *SectionInsertRequest) error {
 	client.info("Moving section %s", request.Section)
 
-	err := client.post(fmt.Sprintf("projects/%s/sections/insert", p.ID), request, nil)
+	err := client.post(ctx, fmt.Sprintf("/projects/%s/sections/insert", p.ID), request, nil)
 	return err
 }
 
@@ -93,9 +129,161 @@ type UpdateSectionRequest struct {
 }
 
 func (s *Section) Update(client *Client, request *UpdateSectionRequest, opts ...*Options) (*Section, error) {
+	return s.UpdateContext(context.Background(), client, request, opts...)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (s *Section) UpdateContext(ctx context.Context, client *Client, request *UpdateSectionRequest, opts ...*Options) (*Section, error) {
 	client.info("Updating section %s", s.ID)
 
 	result := &Section{}
-	err := client.put(fmt.Sprintf("/sections/%s", s.ID), request, result, opts...)
+	err := client.put(ctx, fmt.Sprintf("/sections/%s", s.ID), request, result, opts...)
 	return result, err
 }
+
+// SectionAddTaskRequest places a task in a section. At most one of
+// InsertBefore and InsertAfter may be given; if neither is, the task is
+// added to the end of the section.
+type SectionAddTaskRequest struct {
+	// Required: The task to add to the section.
+	Task string `json:"task"`
+
+	// A task in the section to insert the task before.
+	InsertBefore string `json:"insert_before,omitempty"`
+
+	// A task in the section to insert the task after.
+	InsertAfter string `json:"insert_after,omitempty"`
+}
+
+// Validate checks the task is given with at most one insert position
+func (r *SectionAddTaskRequest) Validate() error {
+	if r.Task == "" {
+		return errors.New("Missing task to add to section")
+	}
+	if r.InsertBefore != "" && r.InsertAfter != "" {
+		return errors.New("Only one of InsertBefore and InsertAfter may be given")
+	}
+	return nil
+}
+
+// AddTask moves a task into this section, removing it from any other
+// section of the project. The task is added to the project if it is not
+// already in it. Tasks already in the section can be moved within it by
+// giving an insert position.
+func (s *Section) AddTask(client *Client, request *SectionAddTaskRequest) error {
+	return s.AddTaskContext(context.Background(), client, request)
+}
+
+// AddTaskContext is like AddTask but uses ctx for the API request
+func (s *Section) AddTaskContext(ctx context.Context, client *Client, request *SectionAddTaskRequest) error {
+	client.trace("Adding task %q to section %q", request.Task, s.ID)
+
+	return client.post(ctx, fmt.Sprintf("/sections/%s/addTask", s.ID), request, nil)
+}
+
+// ReorderTasks moves tasks so that this section contains taskIDs in the
+// given order, followed by any other tasks already in the section in their
+// current order. Listed tasks which are not in the section are moved into
+// it.
+//
+// The tasks in the longest run which is already in the right relative
+// order are left in place, so the number of tasks moved is as small as
+// possible.
+func (s *Section) ReorderTasks(client *Client, taskIDs []string) error {
+	return s.ReorderTasksContext(context.Background(), client, taskIDs)
+}
+
+// ReorderTasksContext is like ReorderTasks but uses ctx for the API requests
+func (s *Section) ReorderTasksContext(ctx context.Context, client *Client, taskIDs []string) error {
+	client.trace("Reordering tasks in section %q", s.ID)
+
+	listed := make(map[string]bool)
+	for _, id := range taskIDs {
+		if listed[id] {
+			return errors.Errorf("Task %q is listed more than once", id)
+		}
+		listed[id] = true
+	}
+
+	tasks, err := s.IterateTasks(ctx, client, &Options{Fields: []string{"gid"}}).All()
+	if err != nil {
+		return err
+	}
+	var current []string
+	for _, task := range tasks {
+		current = append(current, task.ID)
+	}
+
+	desired := append([]string{}, taskIDs...)
+	for _, id := range current {
+		if !listed[id] {
+			desired = append(desired, id)
+		}
+	}
+	fixed := stableTasks(current, desired)
+
+	firstFixed := ""
+	for _, id := range desired {
+		if fixed[id] {
+			firstFixed = id
+			break
+		}
+	}
+	for i, id := range desired {
+		if fixed[id] {
+			continue
+		}
+		request := &SectionAddTaskRequest{Task: id}
+		if i > 0 {
+			request.InsertAfter = desired[i-1]
+		} else {
+			request.InsertBefore = firstFixed
+		}
+		if err := s.AddTaskContext(ctx, client, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stableTasks returns the largest set of tasks which are in the same
+// relative order in current and desired, found as the longest increasing
+// subsequence of the current positions of the desired tasks
+func stableTasks(current, desired []string) map[string]bool {
+	position := make(map[string]int)
+	for i, id := range current {
+		position[id] = i
+	}
+
+	// tails[k] is the index in desired of the smallest possible last
+	// element of an increasing run of length k+1, and previous links each
+	// element to the one before it in its run
+	var tails []int
+	previous := make([]int, len(desired))
+	for i, id := range desired {
+		p, ok := position[id]
+		if !ok {
+			continue
+		}
+		k := sort.Search(len(tails), func(k int) bool {
+			return position[desired[tails[k]]] >= p
+		})
+		previous[i] = -1
+		if k > 0 {
+			previous[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	result := make(map[string]bool)
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+			result[desired[i]] = true
+		}
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+package asana_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func sectionTaskNames(t *testing.T, client *asana.Client, section *asana.Section) string {
+	t.Helper()
+	tasks, err := section.IterateTasks(context.Background(), client, &asana.Options{Fields: []string{"name"}}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Join(names(tasks), " ")
+}
+
+func TestSectionAddTask(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Project"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo, err := project.CreateSection(client, &asana.SectionBase{Name: "To do"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, err := project.CreateSection(client, &asana.SectionBase{Name: "Done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := project.InsertSection(client, &asana.SectionInsertRequest{
+		Section:       done.ID,
+		BeforeSection: todo.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sections, err := project.IterateSections(context.Background(), client).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, section := range sections {
+		order = append(order, section.Name)
+	}
+	if actual := strings.Join(order, ", "); !strings.HasSuffix(actual, "Done, To do") {
+		t.Errorf("Expected Done before To do, but saw %s", actual)
+	}
+
+	tasks := make(map[string]*asana.Task)
+	for _, name := range []string{"a", "b", "c"} {
+		task, err := client.CreateTask(&asana.CreateTaskRequest{
+			TaskBase:  asana.TaskBase{Name: name},
+			Workspace: workspace.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks[name] = task
+		if err := todo.AddTask(client, &asana.SectionAddTaskRequest{Task: task.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if actual := sectionTaskNames(t, client, todo); actual != "a b c" {
+		t.Errorf("Expected a b c, but saw %s", actual)
+	}
+
+	if err := todo.AddTask(client, &asana.SectionAddTaskRequest{Task: tasks["c"].ID, InsertBefore: tasks["a"].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := todo.AddTask(client, &asana.SectionAddTaskRequest{Task: tasks["a"].ID, InsertAfter: tasks["b"].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := sectionTaskNames(t, client, todo); actual != "c b a" {
+		t.Errorf("Expected c b a, but saw %s", actual)
+	}
+
+	// Adding a task to another section moves it
+	if err := done.AddTask(client, &asana.SectionAddTaskRequest{Task: tasks["b"].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := sectionTaskNames(t, client, todo); actual != "c a" {
+		t.Errorf("Expected c a, but saw %s", actual)
+	}
+	if actual := sectionTaskNames(t, client, done); actual != "b" {
+		t.Errorf("Expected b, but saw %s", actual)
+	}
+
+	if err := todo.AddTask(client, &asana.SectionAddTaskRequest{
+		Task:         tasks["b"].ID,
+		InsertBefore: tasks["a"].ID,
+		InsertAfter:  tasks["c"].ID,
+	}); err == nil {
+		t.Error("Expected an error giving both insert positions")
+	}
+}
+
+func TestSectionReorderTasks(t *testing.T) {
+	for _, test := range []struct {
+		current string
+		order   string
+		result  string
+		moves   int
+	}{
+		{"a b c d e", "a b c d e", "a b c d e", 0},
+		{"a b c d e", "e", "e a b c d", 1},
+		{"a b c d e", "b c d e a", "b c d e a", 1},
+		{"a b c d e", "e d c b a", "e d c b a", 4},
+		{"a b c d e", "a c e b d", "a c e b d", 2},
+		{"a b c d e", "d a", "d a b c e", 1},
+		{"a b", "x b a", "x b a", 2},
+		{"", "x y", "x y", 2},
+	} {
+		t.Run(test.current+" -> "+test.order, func(t *testing.T) {
+			client, server := asanatest.NewClient(t)
+			workspace := server.AddWorkspace("Workspace")
+
+			project, err := client.CreateProject(&asana.CreateProjectRequest{
+				ProjectBase: asana.ProjectBase{Name: "Project"},
+				Workspace:   workspace.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			section, err := project.CreateSection(client, &asana.SectionBase{Name: "Section"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make(map[string]string)
+			for _, name := range strings.Fields("a b c d e x y") {
+				task, err := client.CreateTask(&asana.CreateTaskRequest{
+					TaskBase:  asana.TaskBase{Name: name},
+					Workspace: workspace.ID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids[name] = task.ID
+			}
+			for _, name := range strings.Fields(test.current) {
+				if err := section.AddTask(client, &asana.SectionAddTaskRequest{Task: ids[name]}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var order []string
+			for _, name := range strings.Fields(test.order) {
+				order = append(order, ids[name])
+			}
+			requests := len(server.Requests())
+			err = section.ReorderTasks(client, order)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			moves := 0
+			for _, request := range server.Requests()[requests:] {
+				if request.Method == "POST" && request.Path == fmt.Sprintf("/sections/%s/addTask", section.ID) {
+					moves++
+				}
+			}
+			if moves != test.moves {
+				t.Errorf("Expected %d moves, but saw %d", test.moves, moves)
+			}
+			if actual := sectionTaskNames(t, client, section); actual != test.result {
+				t.Errorf("Expected %s, but saw %s", test.result, actual)
+			}
+		})
+	}
+}
+
+func TestSectionReorderTasks_Duplicates(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	section := &asana.Section{ID: "1"}
+	if err := section.ReorderTasks(client, []string{"2", "3", "2"}); err == nil {
+		t.Error("Expected an error for duplicate task IDs")
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Errorf("Expected no requests, but saw %d", n)
+	}
+}
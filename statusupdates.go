@@ -0,0 +1,216 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StatusType is the overall state reported by a status update
+type StatusType string
+
+// Status types for projects, portfolios and goals
+const (
+	StatusOnTrack  StatusType = "on_track"
+	StatusAtRisk   StatusType = "at_risk"
+	StatusOffTrack StatusType = "off_track"
+	StatusOnHold   StatusType = "on_hold"
+	StatusComplete StatusType = "complete"
+)
+
+// StatusUpdateBase contains the fields which are set when a status update is
+// posted
+type StatusUpdateBase struct {
+	// The title of the status update.
+	Title string `json:"title,omitempty"`
+
+	// The text content of the status update.
+	Text string `json:"text,omitempty"`
+
+	// The text content of the status update with formatting as HTML. Only
+	// one of Text and HTMLText should be given.
+	HTMLText string `json:"html_text,omitempty"`
+
+	// The overall state of the parent object.
+	StatusType StatusType `json:"status_type,omitempty"`
+}
+
+// StatusUpdate is a report on the progress of a project, portfolio or goal.
+// Status updates cannot be edited once posted.
+type StatusUpdate struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	StatusUpdateBase
+
+	// Read-only. The kind of object the update was posted on, such as
+	// project_status_update.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// Read-only. The project, portfolio or goal the update was posted on.
+	Parent *Resource `json:"parent,omitempty"`
+
+	// Read-only. The user who wrote the status update.
+	Author *User `json:"author,omitempty"`
+
+	// Read-only. The user who posted the status update.
+	CreatedBy *User `json:"created_by,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The time at which this object was last modified.
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+
+	// Read-only. The number of users who have liked this status update.
+	NumLikes int32 `json:"num_likes,omitempty"`
+}
+
+// Fetch loads the full details for this StatusUpdate
+func (s *StatusUpdate) Fetch(client *Client, opts ...*Options) error {
+	return s.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (s *StatusUpdate) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading status update details for %q", s.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/status_updates/%s", s.ID), nil, s, opts...)
+	return err
+}
+
+// Delete removes this status update
+func (s *StatusUpdate) Delete(client *Client) error {
+	return s.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (s *StatusUpdate) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting status update %q", s.ID)
+
+	return client.delete(ctx, fmt.Sprintf("/status_updates/%s", s.ID))
+}
+
+// CreateStatusUpdateRequest represents a request to post a status update
+type CreateStatusUpdateRequest struct {
+	StatusUpdateBase
+
+	// Required: The project, portfolio or goal to post the update on.
+	Parent string `json:"parent"`
+}
+
+// Validate checks the parent, status and text of the update are given
+func (r *CreateStatusUpdateRequest) Validate() error {
+	if r.Parent == "" {
+		return errors.New("Missing parent for status update")
+	}
+	if r.StatusType == "" {
+		return errors.New("Missing status type for status update")
+	}
+	if r.Text == "" && r.HTMLText == "" {
+		return errors.New("Missing text for status update")
+	}
+	return nil
+}
+
+// CreateStatusUpdate posts a status update on a project, portfolio or goal
+func (c *Client) CreateStatusUpdate(request *CreateStatusUpdateRequest) (*StatusUpdate, error) {
+	return c.CreateStatusUpdateContext(context.Background(), request)
+}
+
+// CreateStatusUpdateContext is like CreateStatusUpdate but uses ctx for the API request
+func (c *Client) CreateStatusUpdateContext(ctx context.Context, request *CreateStatusUpdateRequest) (*StatusUpdate, error) {
+	c.info("Posting %s status update on %q\n", request.StatusType, request.Parent)
+
+	result := &StatusUpdate{}
+	err := c.post(ctx, "/status_updates", request, result)
+	return result, err
+}
+
+type statusUpdatesQuery struct {
+	Parent       string `url:"parent"`
+	CreatedSince string `url:"created_since,omitempty"`
+}
+
+// StatusUpdates returns the status updates posted on a project, portfolio
+// or goal, newest first. If createdSince is not nil only updates created
+// after that time are returned.
+func (c *Client) StatusUpdates(parentID string, createdSince *time.Time, opts ...*Options) ([]*StatusUpdate, *NextPage, error) {
+	return c.StatusUpdatesContext(context.Background(), parentID, createdSince, opts...)
+}
+
+// StatusUpdatesContext is like StatusUpdates but uses ctx for the API request
+func (c *Client) StatusUpdatesContext(ctx context.Context, parentID string, createdSince *time.Time, opts ...*Options) ([]*StatusUpdate, *NextPage, error) {
+	c.trace("Listing status updates on %q", parentID)
+
+	var result []*StatusUpdate
+
+	// Make the request
+	query := &statusUpdatesQuery{
+		Parent: parentID,
+	}
+	if createdSince != nil {
+		// Keep the fractional seconds, which the default encoding drops
+		query.CreatedSince = createdSince.Format(time.RFC3339Nano)
+	}
+	nextPage, err := c.get(ctx, "/status_updates", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateStatusUpdates returns an Iterator over the status updates posted on
+// a project, portfolio or goal, filtered as for StatusUpdates
+func (c *Client) IterateStatusUpdates(ctx context.Context, parentID string, createdSince *time.Time, opts ...*Options) *Iterator[*StatusUpdate] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*StatusUpdate, *NextPage, error) {
+		return c.StatusUpdatesContext(ctx, parentID, createdSince, append([]*Options{page}, opts...)...)
+	})
+}
+
+// CreateStatusUpdate posts a status update on this project
+func (p *Project) CreateStatusUpdate(client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return p.CreateStatusUpdateContext(context.Background(), client, update)
+}
+
+// CreateStatusUpdateContext is like CreateStatusUpdate but uses ctx for the API request
+func (p *Project) CreateStatusUpdateContext(ctx context.Context, client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return client.CreateStatusUpdateContext(ctx, &CreateStatusUpdateRequest{StatusUpdateBase: *update, Parent: p.ID})
+}
+
+// IterateStatusUpdates returns an Iterator over the status updates posted on
+// this project, newest first
+func (p *Project) IterateStatusUpdates(ctx context.Context, client *Client, opts ...*Options) *Iterator[*StatusUpdate] {
+	return client.IterateStatusUpdates(ctx, p.ID, nil, opts...)
+}
+
+// CreateStatusUpdate posts a status update on this portfolio
+func (p *Portfolio) CreateStatusUpdate(client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return p.CreateStatusUpdateContext(context.Background(), client, update)
+}
+
+// CreateStatusUpdateContext is like CreateStatusUpdate but uses ctx for the API request
+func (p *Portfolio) CreateStatusUpdateContext(ctx context.Context, client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return client.CreateStatusUpdateContext(ctx, &CreateStatusUpdateRequest{StatusUpdateBase: *update, Parent: p.ID})
+}
+
+// IterateStatusUpdates returns an Iterator over the status updates posted on
+// this portfolio, newest first
+func (p *Portfolio) IterateStatusUpdates(ctx context.Context, client *Client, opts ...*Options) *Iterator[*StatusUpdate] {
+	return client.IterateStatusUpdates(ctx, p.ID, nil, opts...)
+}
+
+// CreateStatusUpdate posts a status update on this goal
+func (g *Goal) CreateStatusUpdate(client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return g.CreateStatusUpdateContext(context.Background(), client, update)
+}
+
+// CreateStatusUpdateContext is like CreateStatusUpdate but uses ctx for the API request
+func (g *Goal) CreateStatusUpdateContext(ctx context.Context, client *Client, update *StatusUpdateBase) (*StatusUpdate, error) {
+	return client.CreateStatusUpdateContext(ctx, &CreateStatusUpdateRequest{StatusUpdateBase: *update, Parent: g.ID})
+}
+
+// IterateStatusUpdates returns an Iterator over the status updates posted on
+// this goal, newest first
+func (g *Goal) IterateStatusUpdates(ctx context.Context, client *Client, opts ...*Options) *Iterator[*StatusUpdate] {
+	return client.IterateStatusUpdates(ctx, g.ID, nil, opts...)
+}
@@ -0,0 +1,91 @@
+package asana_test
+
+import (
+	"context"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestStatusUpdates(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Launch"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []*asana.StatusUpdate
+	for _, status := range []asana.StatusType{asana.StatusOnTrack, asana.StatusAtRisk, asana.StatusOffTrack} {
+		update, err := project.CreateStatusUpdate(client, &asana.StatusUpdateBase{
+			Title:      "Weekly update",
+			HTMLText:   "<body>Status is <strong>" + string(status) + "</strong></body>",
+			StatusType: status,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		updates = append(updates, update)
+	}
+	if updates[0].ResourceSubtype != "project_status_update" || updates[0].Parent.Project() == nil {
+		t.Errorf("Unexpected status update %+v", updates[0])
+	}
+	if _, err := project.CreateStatusUpdate(client, &asana.StatusUpdateBase{Text: "Missing status"}); err == nil {
+		t.Error("Expected a validation error")
+	}
+
+	history, err := project.IterateStatusUpdates(context.Background(), client, &asana.Options{Limit: 2}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[0].ID != updates[2].ID || history[2].ID != updates[0].ID {
+		t.Errorf("Unexpected history %v", history)
+	}
+	since, _, err := client.StatusUpdates(project.ID, updates[2].CreatedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(since) != 0 {
+		t.Errorf("Unexpected updates since %v: %v", updates[2].CreatedAt, since)
+	}
+
+	// Deleting the latest update restores the previous one
+	if err := updates[2].Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := project.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if project.CurrentStatusUpdate == nil || project.CurrentStatusUpdate.ID != updates[1].ID {
+		t.Errorf("Unexpected current status update %v", project.CurrentStatusUpdate)
+	}
+	fetched := &asana.StatusUpdate{ID: updates[1].ID}
+	if err := fetched.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if fetched.StatusType != asana.StatusAtRisk || fetched.Author == nil {
+		t.Errorf("Unexpected status update %+v", fetched)
+	}
+
+	goal, err := client.CreateGoal(&asana.CreateGoalRequest{
+		GoalBase:  asana.GoalBase{Name: "Launch on time"},
+		Workspace: workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := goal.CreateStatusUpdate(client, &asana.StatusUpdateBase{Text: "Done", StatusType: asana.StatusComplete}); err != nil {
+		t.Fatal(err)
+	}
+	goalUpdates, err := goal.IterateStatusUpdates(context.Background(), client).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goalUpdates) != 1 {
+		t.Errorf("Unexpected goal updates %v", goalUpdates)
+	}
+}
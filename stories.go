@@ -1,6 +1,7 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -95,10 +96,10 @@ type StorySubtypeFields struct {
 
 	// Present for dependency_added, dependency_removed, dependency_marked_complete, dependency_marked_incomplete,
 	// dependency_due_date_changed
-	Dependency *Task `json:"duplicated_from,omitempty"`
+	Dependency *Task `json:"dependency,omitempty"`
 
 	// Present for dependent_added, dependent_removed
-	Dependent *Task `json:"duplicated_from,omitempty"`
+	Dependent *Task `json:"dependent,omitempty"`
 }
 
 // Story represents an activity associated with an object in the Asana
@@ -151,22 +152,39 @@ type Story struct {
 
 // Stories lists all stories attached to a task
 func (t *Task) Stories(client *Client, opts ...*Options) ([]*Story, *NextPage, error) {
+	return t.StoriesContext(context.Background(), client, opts...)
+}
+
+// StoriesContext is like Stories but uses ctx for the API request
+func (t *Task) StoriesContext(ctx context.Context, client *Client, opts ...*Options) ([]*Story, *NextPage, error) {
 	client.trace("Listing stories for %q", t.Name)
 
 	var result []*Story
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/tasks/%s/stories", t.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/stories", t.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateStories returns an Iterator over the stories attached to this task
+func (t *Task) IterateStories(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Story] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Story, *NextPage, error) {
+		return t.StoriesContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // CreateComment adds a comment story to a task
 func (t *Task) CreateComment(client *Client, story *StoryBase) (*Story, error) {
+	return t.CreateCommentContext(context.Background(), client, story)
+}
+
+// CreateCommentContext is like CreateComment but uses ctx for the API request
+func (t *Task) CreateCommentContext(ctx context.Context, client *Client, story *StoryBase) (*Story, error) {
 	client.info("Creating comment for task %q", t.Name)
 
 	result := &Story{}
 
-	err := client.post(fmt.Sprintf("/tasks/%s/stories", t.ID), story, result)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/stories", t.ID), story, result)
 	return result, err
 }
 
@@ -174,17 +192,27 @@ func (t *Task) CreateComment(client *Client, story *StoryBase) (*Story, error) {
 // Only comment stories can have their text updated, and only comment stories and attachment stories can be pinned.
 // Only one of text and html_text can be specified.
 func (s *Story) UpdateStory(client *Client, story *StoryBase) (*Story, error) {
+	return s.UpdateStoryContext(context.Background(), client, story)
+}
+
+// UpdateStoryContext is like UpdateStory but uses ctx for the API request
+func (s *Story) UpdateStoryContext(ctx context.Context, client *Client, story *StoryBase) (*Story, error) {
 	client.info("Updating story %s", s.ID)
 
 	result := &Story{}
 
-	err := client.put(fmt.Sprintf("/stories/%s", s.ID), story, result)
+	err := client.put(ctx, fmt.Sprintf("/stories/%s", s.ID), story, result)
 	return result, err
 }
 
 func (s *Story) Delete(client *Client) error {
+	return s.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (s *Story) DeleteContext(ctx context.Context, client *Client) error {
 	client.trace("Delete story %s %s", s.ID, s.ResourceSubtype)
 
-	err := client.delete(fmt.Sprintf("/stories/%s", s.ID))
+	err := client.delete(ctx, fmt.Sprintf("/stories/%s", s.ID))
 	return err
 }
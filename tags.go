@@ -1,8 +1,11 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // TagBase contains the modifiable fields for a Tag
@@ -51,58 +54,211 @@ type Tag struct {
 
 // Fetch loads the full details for this Tag
 func (t *Tag) Fetch(client *Client, options ...*Options) error {
+	return t.FetchContext(context.Background(), client, options...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (t *Tag) FetchContext(ctx context.Context, client *Client, options ...*Options) error {
 	client.trace("Loading details for tag %q", t.Name)
 
-	_, err := client.get(fmt.Sprintf("/tags/%s", t.ID), nil, t, options...)
+	_, err := client.get(ctx, fmt.Sprintf("/tags/%s", t.ID), nil, t, options...)
 	return err
 }
 
 // Tags returns a list of tags in this workspace
 func (w *Workspace) Tags(client *Client, options ...*Options) ([]*Tag, *NextPage, error) {
+	return w.TagsContext(context.Background(), client, options...)
+}
+
+// TagsContext is like Tags but uses ctx for the API request
+func (w *Workspace) TagsContext(ctx context.Context, client *Client, options ...*Options) ([]*Tag, *NextPage, error) {
 	client.trace("Listing tags in %q", w.Name)
 
 	var result []*Tag
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/workspaces/%s/tags", w.ID), nil, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/workspaces/%s/tags", w.ID), nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateTags returns an Iterator over the tags in this workspace
+func (w *Workspace) IterateTags(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Tag] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Tag, *NextPage, error) {
+		return w.TagsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllTags repeatedly pages through all available tags in a workspace
 func (w *Workspace) AllTags(client *Client, options ...*Options) ([]*Tag, error) {
-	allTags := []*Tag{}
-	nextPage := &NextPage{}
-
-	var tags []*Tag
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		tags, nextPage, err = w.Tags(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return w.AllTagsContext(context.Background(), client, options...)
+}
 
-		allTags = append(allTags, tags...)
-	}
-	return allTags, nil
+// AllTagsContext is like AllTags but uses ctx for the API requests
+func (w *Workspace) AllTagsContext(ctx context.Context, client *Client, options ...*Options) ([]*Tag, error) {
+	it := w.IterateTags(ctx, client, options...)
+	it.PageSize = 50
+	return it.All()
 }
 
 // CreateTag adds a new tag to a workspace
 func (w *Workspace) CreateTag(client *Client, tag *TagBase, options ...*Options) (*Tag, error) {
+	return w.CreateTagContext(context.Background(), client, tag, options...)
+}
+
+// CreateTagContext is like CreateTag but uses ctx for the API request
+func (w *Workspace) CreateTagContext(ctx context.Context, client *Client, tag *TagBase, options ...*Options) (*Tag, error) {
 	client.info("Creating tag %q in %q\n", tag.Name, w.Name)
 
 	result := &Tag{}
 
-	err := client.post(fmt.Sprintf("/workspaces/%s/tags", w.ID), tag, result, options...)
+	err := client.post(ctx, fmt.Sprintf("/workspaces/%s/tags", w.ID), tag, result, options...)
 	if err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
+
+// UpdateTagRequest changes the fields of a tag. Fields which are nil are
+// left unchanged, so notes can be cleared by setting Notes to an empty
+// string, and the color removed by setting Color to an empty string.
+type UpdateTagRequest struct {
+	Name  *string
+	Notes *string
+	Color *string
+}
+
+func (r *UpdateTagRequest) encode() map[string]interface{} {
+	m := map[string]interface{}{}
+	if r.Name != nil {
+		m["name"] = *r.Name
+	}
+	if r.Notes != nil {
+		m["notes"] = *r.Notes
+	}
+	if r.Color != nil {
+		if *r.Color == "" {
+			m["color"] = nil
+		} else {
+			m["color"] = *r.Color
+		}
+	}
+	return m
+}
+
+// Update applies new values to a Tag record
+func (t *Tag) Update(client *Client, request *UpdateTagRequest, opts ...*Options) error {
+	return t.UpdateContext(context.Background(), client, request, opts...)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (t *Tag) UpdateContext(ctx context.Context, client *Client, request *UpdateTagRequest, opts ...*Options) error {
+	client.trace("Updating tag %q", t.ID)
+
+	// Decode into a new record, as a null color would leave the old value
+	result := &Tag{}
+	if err := client.put(ctx, fmt.Sprintf("/tags/%s", t.ID), request.encode(), result, opts...); err != nil {
+		return err
+	}
+	*t = *result
+	return nil
+}
+
+// Delete removes this tag from the workspace and from any tasks it is on
+func (t *Tag) Delete(client *Client) error {
+	return t.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (t *Tag) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting tag %q", t.Name)
+
+	return client.delete(ctx, fmt.Sprintf("/tags/%s", t.ID))
+}
+
+// Tasks returns a list of tasks with this tag
+func (t *Tag) Tasks(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return t.TasksContext(context.Background(), client, opts...)
+}
+
+// TasksContext is like Tasks but uses ctx for the API request
+func (t *Tag) TasksContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	client.trace("Listing tasks with tag %q", t.Name)
+	var result []*Task
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tags/%s/tasks", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateTasks returns an Iterator over the tasks with this tag
+func (t *Tag) IterateTasks(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return t.TasksContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// AddTag adds a tag to this task. Adding a tag which is already on the task
+// has no effect.
+func (t *Task) AddTag(client *Client, tagID string) error {
+	return t.AddTagContext(context.Background(), client, tagID)
+}
+
+// AddTagContext is like AddTag but uses ctx for the API request
+func (t *Task) AddTagContext(ctx context.Context, client *Client, tagID string) error {
+	client.trace("Adding tag %q to task %q", tagID, t.ID)
+
+	return client.post(ctx, fmt.Sprintf("/tasks/%s/addTag", t.ID), map[string]interface{}{
+		"tag": tagID,
+	}, nil)
+}
+
+// RemoveTag removes a tag from this task
+func (t *Task) RemoveTag(client *Client, tagID string) error {
+	return t.RemoveTagContext(context.Background(), client, tagID)
+}
+
+// RemoveTagContext is like RemoveTag but uses ctx for the API request
+func (t *Task) RemoveTagContext(ctx context.Context, client *Client, tagID string) error {
+	client.trace("Removing tag %q from task %q", tagID, t.ID)
+
+	return client.post(ctx, fmt.Sprintf("/tasks/%s/removeTag", t.ID), map[string]interface{}{
+		"tag": tagID,
+	}, nil)
+}
+
+// GetOrCreateTag returns the tag in this workspace with the same name as the
+// provided tag, creating it if there is none. Names are compared exactly;
+// if several tags share the name the oldest is returned.
+func (w *Workspace) GetOrCreateTag(client *Client, tag *TagBase) (*Tag, error) {
+	return w.GetOrCreateTagContext(context.Background(), client, tag)
+}
+
+// GetOrCreateTagContext is like GetOrCreateTag but uses ctx for the API requests
+func (w *Workspace) GetOrCreateTagContext(ctx context.Context, client *Client, tag *TagBase) (*Tag, error) {
+	if tag.Name == "" {
+		return nil, errors.New("A tag name is required")
+	}
+
+	it := w.IterateTags(ctx, client, &Options{Fields: []string{"name", "color", "notes", "created_at"}})
+	defer it.Close()
+
+	var found *Tag
+	for it.Next() {
+		existing := it.Value()
+		if existing.Name != tag.Name {
+			continue
+		}
+		if found == nil || existing.CreatedAt != nil && found.CreatedAt != nil && existing.CreatedAt.Before(*found.CreatedAt) {
+			found = existing
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	return w.CreateTagContext(ctx, client, tag)
+}
@@ -0,0 +1,76 @@
+package asana_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestTagTasks(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+
+	tag, err := workspace.GetOrCreateTag(client, &asana.TagBase{Name: "urgent", Notes: "Needs attention", Color: "dark-red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := workspace.GetOrCreateTag(client, &asana.TagBase{Name: "urgent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != tag.ID {
+		t.Errorf("Expected existing tag %s, but saw %s", tag.ID, again.ID)
+	}
+
+	var tasks []*asana.Task
+	for i := 0; i < 5; i++ {
+		task, err := client.CreateTask(&asana.CreateTaskRequest{
+			TaskBase:  asana.TaskBase{Name: fmt.Sprintf("Task %d", i)},
+			Workspace: workspace.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := task.AddTag(client, tag.ID); err != nil {
+			t.Fatal(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := tasks[0].RemoveTag(client, tag.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	it := tag.IterateTasks(context.Background(), client)
+	it.PageSize = 2
+	tagged, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 4 || tagged[0].ID != tasks[1].ID {
+		t.Errorf("Unexpected tagged tasks %v", tagged)
+	}
+
+	name, empty := "critical", ""
+	if err := tag.Update(client, &asana.UpdateTagRequest{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "critical" || tag.Color != "dark-red" {
+		t.Errorf("Unexpected updated tag %+v", tag.TagBase)
+	}
+	if err := tag.Update(client, &asana.UpdateTagRequest{Notes: &empty, Color: &empty}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "critical" || tag.Notes != "" || tag.Color != "" {
+		t.Errorf("Expected notes and color to be cleared, but saw %+v", tag.TagBase)
+	}
+
+	if err := tag.Delete(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := tag.Fetch(client); !asana.IsNotFoundError(err) {
+		t.Errorf("Expected not found error, but saw %v", err)
+	}
+}
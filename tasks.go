@@ -1,8 +1,12 @@
 package asana
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // TaskQuery specifies which tasks to return from QueryTasks
@@ -22,6 +26,9 @@ type TaskQuery struct {
 	// Note: Currently, this is only supported in board views.
 	Section string `url:"section,omitempty"`
 
+	// The user task list to filter tasks on
+	UserTaskList string `url:"user_task_list,omitempty"`
+
 	// The workspace or organization to filter tasks on.
 	//
 	// Note: If you specify workspace, you must also specify the assignee to filter on.
@@ -174,9 +181,10 @@ type CreateMembership struct {
 type UpdateTaskRequest struct {
 	TaskBase
 
-	Assignee     string                 `json:"assignee,omitempty"`  // User to which this task is assigned, or null if the task is unassigned.
-	Followers    []string               `json:"followers,omitempty"` // Array of users following this task.
-	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	Assignee        string                 `json:"assignee,omitempty"`         // User to which this task is assigned, or null if the task is unassigned.
+	AssigneeSection string                 `json:"assignee_section,omitempty"` // Section of the assignee's user task list to move the task to.
+	Followers       []string               `json:"followers,omitempty"`        // Array of users following this task.
+	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 // Task is the basic object around which many operations in Asana are
@@ -234,6 +242,8 @@ type Task struct {
 	// subset of members who receive all notifications for a project, the
 	// default notification setting when adding members to a project in-
 	// product.
+	//
+	// Use AddFollowers and RemoveFollowers to change the followers.
 	Followers []*User `json:"followers,omitempty"`
 
 	// User to which this task is assigned, or null if the task is unassigned.
@@ -243,10 +253,18 @@ type Task struct {
 	// field can only be set if the assignee is non-null.
 	AssigneeStatus string `json:"assignee_status,omitempty"`
 
+	// The section of the assignee's user task list which this task is in,
+	// or null if the task is unassigned.
+	AssigneeSection *Section `json:"assignee_section,omitempty"`
+
 	// Read-only. The time at which this task was completed, or null if the
 	// task is incomplete.
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
+	// Read-only. The total time in minutes logged in the time tracking
+	// entries of this task, or null if no time has been logged.
+	ActualTimeMinutes *float64 `json:"actual_time_minutes,omitempty"`
+
 	// Array of custom fields applied to the task. These custom fields
 	// represent the values recorded on this task for a particular custom
 	// field. For example, these fields will contain an enum_value property
@@ -273,7 +291,7 @@ type Task struct {
 
 	// Create-only. Array of tags associated with this task. This property may
 	// be specified on creation using just an array of tag IDs. In order to
-	// change tags on an existing task use addTag and removeTag.
+	// change tags on an existing task use AddTag and RemoveTag.
 	Tags []*Tag `json:"tags,omitempty"`
 
 	// Read-only. Array of resources referencing tasks that this task depends on.
@@ -287,24 +305,126 @@ type Task struct {
 
 // Fetch loads the full details for this Task
 func (t *Task) Fetch(client *Client, opts ...*Options) error {
+	return t.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (t *Task) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
 	client.trace("Loading task details for %q", t.Name)
 
-	_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, t, opts...)
+	_, err := client.get(ctx, fmt.Sprintf("/tasks/%s", t.ID), nil, t, opts...)
 	return err
 }
 
 // Update applies new values to a Task record
 func (t *Task) Update(client *Client, update *UpdateTaskRequest) error {
+	return t.UpdateContext(context.Background(), client, update)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (t *Task) UpdateContext(ctx context.Context, client *Client, update *UpdateTaskRequest) error {
 	client.trace("Updating task %q", t.Name)
 
-	err := client.put(fmt.Sprintf("/tasks/%s", t.ID), update, t)
+	err := client.put(ctx, fmt.Sprintf("/tasks/%s", t.ID), update, t)
 	return err
 }
 
 func (t *Task) Delete(client *Client) error {
+	return t.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (t *Task) DeleteContext(ctx context.Context, client *Client) error {
 	client.info("Deleting task %q", t.Name)
 
-	return client.delete(fmt.Sprintf("/tasks/%s", t.ID))
+	return client.delete(ctx, fmt.Sprintf("/tasks/%s", t.ID))
+}
+
+// TaskDuplicateOption selects optional parts of a task to copy when it is
+// duplicated
+type TaskDuplicateOption string
+
+// Task duplication options
+const (
+	TaskDuplicateNotes        TaskDuplicateOption = "notes"
+	TaskDuplicateAssignee     TaskDuplicateOption = "assignee"
+	TaskDuplicateSubtasks     TaskDuplicateOption = "subtasks"
+	TaskDuplicateAttachments  TaskDuplicateOption = "attachments"
+	TaskDuplicateTags         TaskDuplicateOption = "tags"
+	TaskDuplicateFollowers    TaskDuplicateOption = "followers"
+	TaskDuplicateProjects     TaskDuplicateOption = "projects"
+	TaskDuplicateDates        TaskDuplicateOption = "dates"
+	TaskDuplicateDependencies TaskDuplicateOption = "dependencies"
+	TaskDuplicateParent       TaskDuplicateOption = "parent"
+)
+
+// DuplicateTaskRequest defines the new task created by Duplicate
+type DuplicateTaskRequest struct {
+	// Required: The name of the new task.
+	Name string
+
+	// The optional parts of the task to copy.
+	Include []TaskDuplicateOption
+}
+
+// Validate checks the request has a name
+func (r *DuplicateTaskRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("Missing name for duplicated task")
+	}
+	return nil
+}
+
+func (r *DuplicateTaskRequest) encode() map[string]interface{} {
+	// Custom request encoding
+	m := map[string]interface{}{
+		"name": r.Name,
+	}
+	if len(r.Include) > 0 {
+		var include []string
+		for _, option := range r.Include {
+			include = append(include, string(option))
+		}
+		m["include"] = strings.Join(include, ",")
+	}
+	return m
+}
+
+// Duplicate starts a job copying this task. Use Job.Wait to wait for the new
+// task to be created, or DuplicateAndWait to do both.
+func (t *Task) Duplicate(client *Client, request *DuplicateTaskRequest) (*Job, error) {
+	return t.DuplicateContext(context.Background(), client, request)
+}
+
+// DuplicateContext is like Duplicate but uses ctx for the API request
+func (t *Task) DuplicateContext(ctx context.Context, client *Client, request *DuplicateTaskRequest) (*Job, error) {
+	client.info("Duplicating task %q as %q", t.ID, request.Name)
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &Job{}
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/duplicate", t.ID), request.encode(), result)
+	return result, err
+}
+
+// DuplicateAndWait copies this task and waits for the job to finish,
+// returning the new task
+func (t *Task) DuplicateAndWait(client *Client, request *DuplicateTaskRequest) (*Task, error) {
+	return t.DuplicateAndWaitContext(context.Background(), client, request)
+}
+
+// DuplicateAndWaitContext is like DuplicateAndWait but uses ctx for the API requests
+func (t *Task) DuplicateAndWaitContext(ctx context.Context, client *Client, request *DuplicateTaskRequest) (*Task, error) {
+	job, err := t.DuplicateContext(ctx, client, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := job.WaitContext(ctx, client); err != nil {
+		return nil, err
+	}
+	return job.NewTask, nil
 }
 
 // AddProjectRequest defines the location a task should be added to a project
@@ -317,8 +437,18 @@ type AddProjectRequest struct {
 
 // AddProject adds this task to an existing project at the provided location
 func (t *Task) AddProject(client *Client, request *AddProjectRequest) error {
+	return t.AddProjectContext(context.Background(), client, request)
+}
+
+// AddProjectContext is like AddProject but uses ctx for the API request
+func (t *Task) AddProjectContext(ctx context.Context, client *Client, request *AddProjectRequest) error {
 	client.trace("Adding task %q to project %q", t.ID, request.Project)
 
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/addProject", t.ID), request.encode(), nil)
+	return err
+}
+
+func (request *AddProjectRequest) encode() map[string]interface{} {
 	// Custom encoding of Insert fields needed
 	m := map[string]interface{}{
 		"project": request.Project,
@@ -339,12 +469,15 @@ func (t *Task) AddProject(client *Client, request *AddProjectRequest) error {
 	if request.Section != "" {
 		m["section"] = request.Section
 	}
-
-	err := client.post(fmt.Sprintf("/tasks/%s/addProject", t.ID), m, nil)
-	return err
+	return m
 }
 
 func (t *Task) RemoveProject(client *Client, projectID string) error {
+	return t.RemoveProjectContext(context.Background(), client, projectID)
+}
+
+// RemoveProjectContext is like RemoveProject but uses ctx for the API request
+func (t *Task) RemoveProjectContext(ctx context.Context, client *Client, projectID string) error {
 	client.trace("Removing task %q from project %q", t.ID, projectID)
 
 	// Custom encoding of Insert fields needed
@@ -352,7 +485,7 @@ func (t *Task) RemoveProject(client *Client, projectID string) error {
 		"project": projectID,
 	}
 
-	err := client.post(fmt.Sprintf("/tasks/%s/removeProject", t.ID), m, nil)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/removeProject", t.ID), m, nil)
 	return err
 }
 
@@ -366,8 +499,18 @@ type SetParentRequest struct {
 
 // SetParent changes the parent of a task
 func (t *Task) SetParent(client *Client, request *SetParentRequest) error {
+	return t.SetParentContext(context.Background(), client, request)
+}
+
+// SetParentContext is like SetParent but uses ctx for the API request
+func (t *Task) SetParentContext(ctx context.Context, client *Client, request *SetParentRequest) error {
 	client.trace("Setting the parent of task %q to %q", t.ID, request.Parent)
 
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/setParent", t.ID), request.encode(), nil)
+	return err
+}
+
+func (request *SetParentRequest) encode() map[string]interface{} {
 	// Custom encoding of Insert fields needed
 	m := map[string]interface{}{
 		"parent": request.Parent,
@@ -382,9 +525,7 @@ func (t *Task) SetParent(client *Client, request *SetParentRequest) error {
 	} else if request.InsertBefore != "" {
 		m["insert_before"] = request.InsertBefore
 	}
-
-	err := client.post(fmt.Sprintf("/tasks/%s/setParent", t.ID), m, nil)
-	return err
+	return m
 }
 
 // AddDependenciesRequest
@@ -396,9 +537,14 @@ type AddDependenciesRequest struct {
 // AddDependencies marks a set of tasks as dependencies of this task, if they
 // are not already dependencies. A task can have at most 15 dependencies.
 func (t *Task) AddDependencies(client *Client, request *AddDependenciesRequest) error {
+	return t.AddDependenciesContext(context.Background(), client, request)
+}
+
+// AddDependenciesContext is like AddDependencies but uses ctx for the API request
+func (t *Task) AddDependenciesContext(ctx context.Context, client *Client, request *AddDependenciesRequest) error {
 	client.trace("Adding dependencies to task %q", t.ID)
 
-	err := client.post(fmt.Sprintf("/tasks/%s/addDependencies", t.ID), request, nil)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/addDependencies", t.ID), request, nil)
 	return err
 }
 
@@ -411,60 +557,193 @@ type AddDependentsRequest struct {
 // AddDependents marks a set of tasks as dependents of this task, if they
 // are not already dependents. A task can have at most 30 dependents.
 func (t *Task) AddDependents(client *Client, request *AddDependentsRequest) error {
+	return t.AddDependentsContext(context.Background(), client, request)
+}
+
+// AddDependentsContext is like AddDependents but uses ctx for the API request
+func (t *Task) AddDependentsContext(ctx context.Context, client *Client, request *AddDependentsRequest) error {
 	client.trace("Adding dependents to task %q", t.ID)
 
-	err := client.post(fmt.Sprintf("/tasks/%s/addDependents", t.ID), request, nil)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/addDependents", t.ID), request, nil)
+	return err
+}
+
+// RemoveDependenciesRequest
+type RemoveDependenciesRequest struct {
+	// Required: An array of task IDs to remove as dependencies.
+	Dependencies []string `json:"dependencies"`
+}
+
+// RemoveDependencies unlinks a set of dependencies from this task
+func (t *Task) RemoveDependencies(client *Client, request *RemoveDependenciesRequest) error {
+	return t.RemoveDependenciesContext(context.Background(), client, request)
+}
+
+// RemoveDependenciesContext is like RemoveDependencies but uses ctx for the API request
+func (t *Task) RemoveDependenciesContext(ctx context.Context, client *Client, request *RemoveDependenciesRequest) error {
+	client.trace("Removing dependencies from task %q", t.ID)
+
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/removeDependencies", t.ID), request, nil)
+	return err
+}
+
+// RemoveDependentsRequest
+type RemoveDependentsRequest struct {
+	// Required: An array of task IDs to remove as dependents.
+	Dependents []string `json:"dependents"`
+}
+
+// RemoveDependents unlinks a set of dependents from this task
+func (t *Task) RemoveDependents(client *Client, request *RemoveDependentsRequest) error {
+	return t.RemoveDependentsContext(context.Background(), client, request)
+}
+
+// RemoveDependentsContext is like RemoveDependents but uses ctx for the API request
+func (t *Task) RemoveDependentsContext(ctx context.Context, client *Client, request *RemoveDependentsRequest) error {
+	client.trace("Removing dependents from task %q", t.ID)
+
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/removeDependents", t.ID), request, nil)
 	return err
 }
 
+// ListDependencies returns a list of the tasks this task depends on
+func (t *Task) ListDependencies(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return t.ListDependenciesContext(context.Background(), client, opts...)
+}
+
+// ListDependenciesContext is like ListDependencies but uses ctx for the API request
+func (t *Task) ListDependenciesContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	client.trace("Listing dependencies of %q", t.Name)
+	var result []*Task
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/dependencies", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateDependencies returns an Iterator over the tasks this task depends on
+func (t *Task) IterateDependencies(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return t.ListDependenciesContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// ListDependents returns a list of the tasks which depend on this task
+func (t *Task) ListDependents(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return t.ListDependentsContext(context.Background(), client, opts...)
+}
+
+// ListDependentsContext is like ListDependents but uses ctx for the API request
+func (t *Task) ListDependentsContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	client.trace("Listing dependents of %q", t.Name)
+	var result []*Task
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/dependents", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateDependents returns an Iterator over the tasks which depend on this task
+func (t *Task) IterateDependents(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return t.ListDependentsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // Tasks returns a list of tasks in this project
 func (p *Project) Tasks(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return p.TasksContext(context.Background(), client, opts...)
+}
+
+// TasksContext is like Tasks but uses ctx for the API request
+func (p *Project) TasksContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
 	client.trace("Listing tasks in %q", p.Name)
 	var result []*Task
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/projects/%s/tasks", p.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/projects/%s/tasks", p.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateTasks returns an Iterator over the tasks in this project
+func (p *Project) IterateTasks(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return p.TasksContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // Tasks returns a list of tasks in this section. Board view only.
 func (s *Section) Tasks(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return s.TasksContext(context.Background(), client, opts...)
+}
+
+// TasksContext is like Tasks but uses ctx for the API request
+func (s *Section) TasksContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
 	client.trace("Listing tasks in %q", s.Name)
 	var result []*Task
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/sections/%s/tasks", s.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/sections/%s/tasks", s.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateTasks returns an Iterator over the tasks in this section
+func (s *Section) IterateTasks(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return s.TasksContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // Subtasks returns a list of tasks in this project
 func (t *Task) Subtasks(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	return t.SubtasksContext(context.Background(), client, opts...)
+}
+
+// SubtasksContext is like Subtasks but uses ctx for the API request
+func (t *Task) SubtasksContext(ctx context.Context, client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
 	client.trace("Listing subtasks for %q", t.Name)
 
 	var result []*Task
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/tasks/%s/subtasks", t.ID), nil, &result, opts...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/subtasks", t.ID), nil, &result, opts...)
 	return result, nextPage, err
 }
 
+// IterateSubtasks returns an Iterator over the subtasks of this task
+func (t *Task) IterateSubtasks(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return t.SubtasksContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // CreateTask creates a new task in the given project
 func (c *Client) CreateTask(task *CreateTaskRequest) (*Task, error) {
+	return c.CreateTaskContext(context.Background(), task)
+}
+
+// CreateTaskContext is like CreateTask but uses ctx for the API request
+func (c *Client) CreateTaskContext(ctx context.Context, task *CreateTaskRequest) (*Task, error) {
 	c.info("Creating task %q", task.Name)
 
 	result := &Task{}
 
-	err := c.post("/tasks", task, result)
+	err := c.post(ctx, "/tasks", task, result)
 	return result, err
 }
 
 // CreateSubtask creates a new task as a subtask of this task
 func (t *Task) CreateSubtask(client *Client, task *Task) (*Task, error) {
+	return t.CreateSubtaskContext(context.Background(), client, task)
+}
+
+// CreateSubtaskContext is like CreateSubtask but uses ctx for the API request
+func (t *Task) CreateSubtaskContext(ctx context.Context, client *Client, task *Task) (*Task, error) {
 	client.info("Creating subtask %q", task.Name)
 
 	result := &Task{}
 
-	err := client.post(fmt.Sprintf("/tasks/%s/subtasks", t.ID), task, result)
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/subtasks", t.ID), task, result)
 	return result, err
 }
 
@@ -476,8 +755,20 @@ func (t *Task) GetID() string {
 // Use one or more of the parameters provided to filter the tasks returned.
 // You must specify a project or tag if you do not specify assignee and workspace.
 func (c *Client) QueryTasks(query *TaskQuery, opts ...*Options) ([]*Task, *NextPage, error) {
+	return c.QueryTasksContext(context.Background(), query, opts...)
+}
+
+// QueryTasksContext is like QueryTasks but uses ctx for the API request
+func (c *Client) QueryTasksContext(ctx context.Context, query *TaskQuery, opts ...*Options) ([]*Task, *NextPage, error) {
 	var result []*Task
 
-	nextPage, err := c.get("/tasks", query, &result, opts...)
+	nextPage, err := c.get(ctx, "/tasks", query, &result, opts...)
 	return result, nextPage, err
 }
+
+// IterateQueryTasks returns an Iterator over the tasks matching query
+func (c *Client) IterateQueryTasks(ctx context.Context, query *TaskQuery, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return c.QueryTasksContext(ctx, query, append([]*Options{page}, opts...)...)
+	})
+}
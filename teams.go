@@ -1,6 +1,7 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -25,44 +26,46 @@ type Team struct {
 
 // Fetch loads the full details for this Team
 func (t *Team) Fetch(client *Client) error {
+	return t.FetchContext(context.Background(), client)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (t *Team) FetchContext(ctx context.Context, client *Client) error {
 	client.trace("Loading team details for %q\n", t.Name)
 
 	// Use fields options to request Organization field which is not returned by default
-	_, err := client.get(fmt.Sprintf("/teams/%s", t.ID), nil, t, Fields(*t))
+	_, err := client.get(ctx, fmt.Sprintf("/teams/%s", t.ID), nil, t, Fields(*t))
 	return err
 }
 
 // Teams returns the compact records for all teams in the organization visible to the authorized user
 func (w *Workspace) Teams(client *Client, options ...*Options) ([]*Team, *NextPage, error) {
+	return w.TeamsContext(context.Background(), client, options...)
+}
+
+// TeamsContext is like Teams but uses ctx for the API request
+func (w *Workspace) TeamsContext(ctx context.Context, client *Client, options ...*Options) ([]*Team, *NextPage, error) {
 	client.trace("Listing teams in workspace %s...\n", w.ID)
 	var result []*Team
 
 	// Make the request
-	nextPage, err := client.get(fmt.Sprintf("/organizations/%s/teams", w.ID), nil, &result, options...)
+	nextPage, err := client.get(ctx, fmt.Sprintf("/organizations/%s/teams", w.ID), nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateTeams returns an Iterator over the teams in this organization visible to the authorized user
+func (w *Workspace) IterateTeams(ctx context.Context, client *Client, opts ...*Options) *Iterator[*Team] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Team, *NextPage, error) {
+		return w.TeamsContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllTeams repeatedly pages through all available teams in a workspace
 func (w *Workspace) AllTeams(client *Client, options ...*Options) ([]*Team, error) {
-	var allTeams []*Team
-	nextPage := &NextPage{}
-
-	var teams []*Team
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		teams, nextPage, err = w.Teams(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
-
-		allTeams = append(allTeams, teams...)
-	}
-	return allTeams, nil
+	return w.AllTeamsContext(context.Background(), client, options...)
+}
+
+// AllTeamsContext is like AllTeams but uses ctx for the API requests
+func (w *Workspace) AllTeamsContext(ctx context.Context, client *Client, options ...*Options) ([]*Team, error) {
+	return w.IterateTeams(ctx, client, options...).All()
 }
@@ -0,0 +1,189 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ProjectTemplate is a reusable outline of a project. Instantiating a
+// template creates a new project, with dates and roles filled in from the
+// request.
+type ProjectTemplate struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. Name of the project template.
+	Name string `json:"name,omitempty"`
+
+	// Read-only. Free-form textual information associated with the template.
+	Description string `json:"description,omitempty"`
+
+	// Read-only. The description of the template with formatting as HTML.
+	HTMLDescription string `json:"html_description,omitempty"`
+
+	// Read-only. Color of the template.
+	Color string `json:"color,omitempty"`
+
+	// Read-only. True if the template is public to its team.
+	Public bool `json:"public,omitempty"`
+
+	// Read-only. The current owner of the template.
+	Owner *User `json:"owner,omitempty"`
+
+	// Read-only. The team that this template is shared with.
+	Team *Team `json:"team,omitempty"`
+
+	// Read-only. The dates which must be given to instantiate the template.
+	RequestedDates []*DateVariable `json:"requested_dates,omitempty"`
+
+	// Read-only. The roles which can be assigned to users when the template
+	// is instantiated.
+	RequestedRoles []*TemplateRole `json:"requested_roles,omitempty"`
+}
+
+// DateVariable is a date in a project template which is chosen when the
+// template is instantiated, such as the project start date
+type DateVariable struct {
+	// Read-only. Globally unique ID of the date variable
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The name of the date variable.
+	Name string `json:"name,omitempty"`
+
+	// Read-only. A description of the date variable.
+	Description string `json:"description,omitempty"`
+}
+
+// TemplateRole is a role in a project template which a user is chosen for
+// when the template is instantiated
+type TemplateRole struct {
+	// Read-only. Globally unique ID of the role
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The name of the role.
+	Name string `json:"name,omitempty"`
+}
+
+// Fetch loads the full details for this ProjectTemplate
+func (t *ProjectTemplate) Fetch(client *Client, opts ...*Options) error {
+	return t.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (t *ProjectTemplate) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading project template details for %q", t.Name)
+
+	_, err := client.get(ctx, fmt.Sprintf("/project_templates/%s", t.ID), nil, t, opts...)
+	return err
+}
+
+// ProjectTemplates returns the project templates in this workspace
+func (w *Workspace) ProjectTemplates(client *Client, opts ...*Options) ([]*ProjectTemplate, *NextPage, error) {
+	return w.ProjectTemplatesContext(context.Background(), client, opts...)
+}
+
+// ProjectTemplatesContext is like ProjectTemplates but uses ctx for the API request
+func (w *Workspace) ProjectTemplatesContext(ctx context.Context, client *Client, opts ...*Options) ([]*ProjectTemplate, *NextPage, error) {
+	client.trace("Listing project templates in %q", w.Name)
+
+	var result []*ProjectTemplate
+
+	// Make the request
+	nextPage, err := client.get(ctx, "/project_templates", &Options{Workspace: w.ID}, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateProjectTemplates returns an Iterator over the project templates in
+// this workspace
+func (w *Workspace) IterateProjectTemplates(ctx context.Context, client *Client, opts ...*Options) *Iterator[*ProjectTemplate] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*ProjectTemplate, *NextPage, error) {
+		return w.ProjectTemplatesContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// ProjectTemplates returns the project templates shared with this team
+func (t *Team) ProjectTemplates(client *Client, opts ...*Options) ([]*ProjectTemplate, *NextPage, error) {
+	return t.ProjectTemplatesContext(context.Background(), client, opts...)
+}
+
+// ProjectTemplatesContext is like ProjectTemplates but uses ctx for the API request
+func (t *Team) ProjectTemplatesContext(ctx context.Context, client *Client, opts ...*Options) ([]*ProjectTemplate, *NextPage, error) {
+	client.trace("Listing project templates in team %q", t.Name)
+
+	var result []*ProjectTemplate
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/teams/%s/project_templates", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateProjectTemplates returns an Iterator over the project templates
+// shared with this team
+func (t *Team) IterateProjectTemplates(ctx context.Context, client *Client, opts ...*Options) *Iterator[*ProjectTemplate] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*ProjectTemplate, *NextPage, error) {
+		return t.ProjectTemplatesContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// DateVariableValue gives the value of a requested date when instantiating
+// a project template
+type DateVariableValue struct {
+	ID    string `json:"gid"`
+	Value *Date  `json:"value"`
+}
+
+// RoleAssignment gives the user for a requested role when instantiating a
+// project template
+type RoleAssignment struct {
+	ID    string `json:"gid"`
+	Value string `json:"value"`
+}
+
+// InstantiateProjectRequest represents a request to create a project from a
+// template
+type InstantiateProjectRequest struct {
+	// Required: The name of the new project.
+	Name string `json:"name"`
+
+	// The team to share the new project with. Defaults to the team of the
+	// template.
+	Team string `json:"team,omitempty"`
+
+	// Sets the project to public to its team.
+	Public *bool `json:"public,omitempty"`
+
+	// If true, the request fails unless every requested date and role is
+	// given.
+	IsStrict *bool `json:"is_strict,omitempty"`
+
+	// Values for the dates in the template's RequestedDates.
+	RequestedDates []*DateVariableValue `json:"requested_dates,omitempty"`
+
+	// Users for the roles in the template's RequestedRoles.
+	RequestedRoles []*RoleAssignment `json:"requested_roles,omitempty"`
+}
+
+// Validate checks the request has a name
+func (r *InstantiateProjectRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("Missing name for new project")
+	}
+	return nil
+}
+
+// Instantiate starts a job creating a new project from this template. Use
+// Job.Wait to wait for the project to be created.
+func (t *ProjectTemplate) Instantiate(client *Client, request *InstantiateProjectRequest) (*Job, error) {
+	return t.InstantiateContext(context.Background(), client, request)
+}
+
+// InstantiateContext is like Instantiate but uses ctx for the API request
+func (t *ProjectTemplate) InstantiateContext(ctx context.Context, client *Client, request *InstantiateProjectRequest) (*Job, error) {
+	client.info("Instantiating project template %q as %q", t.ID, request.Name)
+
+	result := &Job{}
+	err := client.post(ctx, fmt.Sprintf("/project_templates/%s/instantiateProject", t.ID), request, result)
+	return result, err
+}
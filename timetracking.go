@@ -0,0 +1,214 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimeTrackingEntryBase contains the fields which are set when time is
+// logged on a task
+type TimeTrackingEntryBase struct {
+	// Time in minutes tracked by the entry.
+	DurationMinutes int `json:"duration_minutes,omitempty"`
+
+	// The day that this entry is logged on.
+	EnteredOn *Date `json:"entered_on,omitempty"`
+}
+
+// TimeTrackingEntry records time spent working on a task
+type TimeTrackingEntry struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	TimeTrackingEntryBase
+
+	// Read-only. The user who logged the time.
+	CreatedBy *User `json:"created_by,omitempty"`
+
+	// Read-only. The task the time was logged on.
+	Task *Task `json:"task,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Fetch loads the full details for this TimeTrackingEntry
+func (e *TimeTrackingEntry) Fetch(client *Client, opts ...*Options) error {
+	return e.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (e *TimeTrackingEntry) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading time tracking entry details for %q", e.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/time_tracking_entries/%s", e.ID), nil, e, opts...)
+	return err
+}
+
+// Update changes the duration or date of this entry. Only fields which are
+// set in the request are changed.
+func (e *TimeTrackingEntry) Update(client *Client, request *TimeTrackingEntryBase) error {
+	return e.UpdateContext(context.Background(), client, request)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (e *TimeTrackingEntry) UpdateContext(ctx context.Context, client *Client, request *TimeTrackingEntryBase) error {
+	client.info("Updating time tracking entry %q", e.ID)
+
+	return client.put(ctx, fmt.Sprintf("/time_tracking_entries/%s", e.ID), request, e)
+}
+
+// Delete removes this time tracking entry
+func (e *TimeTrackingEntry) Delete(client *Client) error {
+	return e.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (e *TimeTrackingEntry) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting time tracking entry %q", e.ID)
+
+	return client.delete(ctx, fmt.Sprintf("/time_tracking_entries/%s", e.ID))
+}
+
+// CreateTimeTrackingEntryRequest represents a request to log time on a task
+type CreateTimeTrackingEntryRequest struct {
+	// Required: Time in minutes to log.
+	DurationMinutes int `json:"duration_minutes"`
+
+	// The day to log the time on. Defaults to today.
+	EnteredOn *Date `json:"entered_on,omitempty"`
+}
+
+// Validate checks the duration is positive
+func (r *CreateTimeTrackingEntryRequest) Validate() error {
+	if r.DurationMinutes <= 0 {
+		return errors.New("Time tracking entries must have a positive duration")
+	}
+	return nil
+}
+
+// CreateTimeTrackingEntry logs time spent on this task
+func (t *Task) CreateTimeTrackingEntry(client *Client, request *CreateTimeTrackingEntryRequest) (*TimeTrackingEntry, error) {
+	return t.CreateTimeTrackingEntryContext(context.Background(), client, request)
+}
+
+// CreateTimeTrackingEntryContext is like CreateTimeTrackingEntry but uses ctx for the API request
+func (t *Task) CreateTimeTrackingEntryContext(ctx context.Context, client *Client, request *CreateTimeTrackingEntryRequest) (*TimeTrackingEntry, error) {
+	client.info("Logging %d minutes on task %q", request.DurationMinutes, t.ID)
+
+	result := &TimeTrackingEntry{}
+	err := client.post(ctx, fmt.Sprintf("/tasks/%s/time_tracking_entries", t.ID), request, result)
+	return result, err
+}
+
+// TimeTrackingEntries returns the compact records for the time logged on
+// this task, which include the duration, date and user
+func (t *Task) TimeTrackingEntries(client *Client, opts ...*Options) ([]*TimeTrackingEntry, *NextPage, error) {
+	return t.TimeTrackingEntriesContext(context.Background(), client, opts...)
+}
+
+// TimeTrackingEntriesContext is like TimeTrackingEntries but uses ctx for the API request
+func (t *Task) TimeTrackingEntriesContext(ctx context.Context, client *Client, opts ...*Options) ([]*TimeTrackingEntry, *NextPage, error) {
+	client.trace("Listing time tracking entries for task %q", t.ID)
+
+	var result []*TimeTrackingEntry
+
+	// Make the request
+	nextPage, err := client.get(ctx, fmt.Sprintf("/tasks/%s/time_tracking_entries", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateTimeTrackingEntries returns an Iterator over the time logged on
+// this task
+func (t *Task) IterateTimeTrackingEntries(ctx context.Context, client *Client, opts ...*Options) *Iterator[*TimeTrackingEntry] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*TimeTrackingEntry, *NextPage, error) {
+		return t.TimeTrackingEntriesContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
+// TimeReport sums the minutes logged in time tracking entries. Totals are
+// kept per user ID, per project ID and per week, with each week keyed by
+// the Monday it starts on.
+type TimeReport struct {
+	TotalMinutes int
+	ByUser       map[string]int
+	ByProject    map[string]int
+	ByWeek       map[Date]int
+}
+
+// NewTimeReport creates an empty TimeReport
+func NewTimeReport() *TimeReport {
+	return &TimeReport{
+		ByUser:    make(map[string]int),
+		ByProject: make(map[string]int),
+		ByWeek:    make(map[Date]int),
+	}
+}
+
+// Add adds the time logged on a task. The minutes are counted towards each
+// of the task's Projects, so the task should have been loaded with its
+// projects field. Entries without a date are not counted in any week.
+func (r *TimeReport) Add(task *Task, entries ...*TimeTrackingEntry) {
+	for _, entry := range entries {
+		minutes := entry.DurationMinutes
+		r.TotalMinutes += minutes
+		if entry.CreatedBy != nil {
+			r.ByUser[entry.CreatedBy.ID] += minutes
+		}
+		for _, project := range task.Projects {
+			r.ByProject[project.ID] += minutes
+		}
+		if entry.EnteredOn != nil {
+			r.ByWeek[weekOf(*entry.EnteredOn)] += minutes
+		}
+	}
+}
+
+// AddTasks loads the time tracking entries of each task and adds them to
+// the report
+func (r *TimeReport) AddTasks(client *Client, tasks ...*Task) error {
+	return r.AddTasksContext(context.Background(), client, tasks...)
+}
+
+// AddTasksContext is like AddTasks but uses ctx for the API requests
+func (r *TimeReport) AddTasksContext(ctx context.Context, client *Client, tasks ...*Task) error {
+	for _, task := range tasks {
+		entries, err := task.IterateTimeTrackingEntries(ctx, client).All()
+		if err != nil {
+			return err
+		}
+		r.Add(task, entries...)
+	}
+	return nil
+}
+
+// weekOf returns the Monday of the week containing a date
+func weekOf(d Date) Date {
+	t := time.Time(d)
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, day := t.AddDate(0, 0, -offset).Date()
+	return Date(time.Date(y, m, day, 0, 0, 0, 0, time.UTC))
+}
+
+// TimeReport loads the tasks in this project and sums the time logged on
+// them. Tasks which are also in other projects count towards those
+// projects too.
+func (p *Project) TimeReport(client *Client) (*TimeReport, error) {
+	return p.TimeReportContext(context.Background(), client)
+}
+
+// TimeReportContext is like TimeReport but uses ctx for the API requests
+func (p *Project) TimeReportContext(ctx context.Context, client *Client) (*TimeReport, error) {
+	client.trace("Summing time tracked in project %q", p.ID)
+
+	tasks, err := p.IterateTasks(ctx, client, &Options{Fields: []string{"name", "projects"}}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewTimeReport()
+	return r, r.AddTasksContext(ctx, client, tasks...)
+}
@@ -0,0 +1,124 @@
+package asana_test
+
+import (
+	"reflect"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestTimeTracking(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	me := server.Me()
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Website"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	design, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{Name: "Design"},
+		Projects: []string{project.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if design.ActualTimeMinutes != nil {
+		t.Errorf("Expected no actual time, but saw %v", *design.ActualTimeMinutes)
+	}
+
+	if _, err := design.CreateTimeTrackingEntry(client, &asana.CreateTimeTrackingEntryRequest{}); err == nil {
+		t.Error("Expected a validation error")
+	}
+	var entries []*asana.TimeTrackingEntry
+	for _, day := range []string{"2024-03-01", "2024-03-04", "2024-03-05"} {
+		entry, err := design.CreateTimeTrackingEntry(client, &asana.CreateTimeTrackingEntryRequest{
+			DurationMinutes: 60,
+			EnteredOn:       date(day),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if entries[0].CreatedBy == nil || entries[0].CreatedBy.ID != me.ID || entries[0].Task.ID != design.ID {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+
+	if err := entries[1].Update(client, &asana.TimeTrackingEntryBase{DurationMinutes: 90}); err != nil {
+		t.Fatal(err)
+	}
+	if entries[1].DurationMinutes != 90 || entries[1].EnteredOn == nil {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+	if err := entries[2].Delete(client); err != nil {
+		t.Fatal(err)
+	}
+
+	listed, _, err := design.TimeTrackingEntries(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || listed[1].DurationMinutes != 90 || listed[1].CreatedBy.ID != me.ID {
+		t.Errorf("Unexpected entries %+v", listed)
+	}
+	if err := design.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if design.ActualTimeMinutes == nil || *design.ActualTimeMinutes != 150 {
+		t.Errorf("Expected 150 minutes, but saw %v", design.ActualTimeMinutes)
+	}
+
+	report, err := project.TimeReport(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &asana.TimeReport{
+		TotalMinutes: 150,
+		ByUser:       map[string]int{me.ID: 150},
+		ByProject:    map[string]int{project.ID: 150},
+		ByWeek: map[asana.Date]int{
+			*date("2024-02-26"): 60,
+			*date("2024-03-04"): 90,
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected %+v, but saw %+v", expected, report)
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	alice, bob := &asana.User{ID: "1"}, &asana.User{ID: "2"}
+	report := asana.NewTimeReport()
+	report.Add(&asana.Task{Projects: []*asana.Project{{ID: "10"}, {ID: "11"}}},
+		&asana.TimeTrackingEntry{
+			TimeTrackingEntryBase: asana.TimeTrackingEntryBase{DurationMinutes: 30, EnteredOn: date("2024-03-10")},
+			CreatedBy:             alice,
+		},
+		&asana.TimeTrackingEntry{
+			TimeTrackingEntryBase: asana.TimeTrackingEntryBase{DurationMinutes: 45, EnteredOn: date("2024-03-11")},
+			CreatedBy:             bob,
+		})
+	report.Add(&asana.Task{},
+		&asana.TimeTrackingEntry{
+			TimeTrackingEntryBase: asana.TimeTrackingEntryBase{DurationMinutes: 15},
+			CreatedBy:             alice,
+		})
+
+	expected := &asana.TimeReport{
+		TotalMinutes: 90,
+		ByUser:       map[string]int{"1": 45, "2": 45},
+		ByProject:    map[string]int{"10": 75, "11": 75},
+		ByWeek: map[asana.Date]int{
+			*date("2024-03-04"): 30,
+			*date("2024-03-11"): 45,
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected %+v, but saw %+v", expected, report)
+	}
+}
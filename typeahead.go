@@ -0,0 +1,254 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Resource types which can be searched with Typeahead
+const (
+	TypeaheadUser      = "user"
+	TypeaheadProject   = "project"
+	TypeaheadTag       = "tag"
+	TypeaheadTask      = "task"
+	TypeaheadPortfolio = "portfolio"
+)
+
+type typeaheadQuery struct {
+	ResourceType string `url:"resource_type"`
+	Query        string `url:"query"`
+	Count        int    `url:"count,omitempty"`
+}
+
+// Typeahead returns up to count objects of the given resource type in this
+// workspace whose names match query, in the order the Asana search box
+// would suggest them. An empty query returns recently viewed objects.
+//
+// The results are compact records. Typeahead is intended for quick lookups
+// and is not paginated; count may be at most 100, or 0 for the default of 20.
+func (w *Workspace) Typeahead(client *Client, resourceType, query string, count int, opts ...*Options) ([]*Resource, error) {
+	return w.TypeaheadContext(context.Background(), client, resourceType, query, count, opts...)
+}
+
+// TypeaheadContext is like Typeahead but uses ctx for the API request
+func (w *Workspace) TypeaheadContext(ctx context.Context, client *Client, resourceType, query string, count int, opts ...*Options) ([]*Resource, error) {
+	client.trace("Searching for %s %q in workspace %q", resourceType, query, w.ID)
+
+	var result []*Resource
+
+	// Make the request
+	q := &typeaheadQuery{
+		ResourceType: resourceType,
+		Query:        query,
+		Count:        count,
+	}
+	_, err := client.get(ctx, fmt.Sprintf("/workspaces/%s/typeahead", w.ID), q, &result, opts...)
+	return result, err
+}
+
+// AmbiguousReferenceError is returned by Resolve when a reference matches
+// more than one object
+type AmbiguousReferenceError struct {
+	Reference    string
+	ResourceType string
+	Matches      []*Resource
+}
+
+func (e *AmbiguousReferenceError) Error() string {
+	var matches []string
+	for _, match := range e.Matches {
+		matches = append(matches, fmt.Sprintf("%q (%s)", match.Name, match.ID))
+	}
+	return fmt.Sprintf("%q matches %d %ss: %s", e.Reference, len(e.Matches), e.ResourceType, strings.Join(matches, ", "))
+}
+
+// UnknownReferenceError is returned by Resolve when a reference does not
+// match any object
+type UnknownReferenceError struct {
+	Reference    string
+	ResourceType string
+}
+
+func (e *UnknownReferenceError) Error() string {
+	return fmt.Sprintf("No %s matches %q", e.ResourceType, e.Reference)
+}
+
+// The number of typeahead results to consider when resolving a name
+const resolveCount = 50
+
+// The fields loaded for each resolved object
+var resolveFields = []string{"name", "resource_type", "resource_subtype"}
+
+// Resolve finds the object of the given resource type, such as "project"
+// or "user", described by ref. The reference may be:
+//
+//   - a GID
+//   - an external ID such as external:my-id
+//   - an email address, for users
+//   - a link to the object in the Asana web app
+//   - the name of the object, which is looked up in the workspace using
+//     Typeahead
+//
+// A reference made only of digits is loaded as a GID, and looked up as a
+// name if there is no object with that GID, so objects named like "2024"
+// can still be found.
+//
+// Names are matched ignoring case. If several objects have the name, or
+// there is no exact match and several names contain ref, an
+// *AmbiguousReferenceError listing the matches is returned. An
+// *UnknownReferenceError is returned if nothing matches.
+//
+// Successful lookups are cached for the lifetime of the Client, so objects
+// which are renamed may still be found by their old name.
+func (c *Client) Resolve(workspaceID, resourceType, ref string) (*Resource, error) {
+	return c.ResolveContext(context.Background(), workspaceID, resourceType, ref)
+}
+
+// ResolveContext is like Resolve but uses ctx for the API requests
+func (c *Client) ResolveContext(ctx context.Context, workspaceID, resourceType, ref string) (*Resource, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, errors.Errorf("Missing %s reference", resourceType)
+	}
+	key := strings.Join([]string{resourceType, workspaceID, ref}, "\x00")
+	if cached, ok := c.resolved.Load(key); ok {
+		result := *cached.(*Resource)
+		return &result, nil
+	}
+
+	c.trace("Resolving %s %q", resourceType, ref)
+	result, err := c.resolve(ctx, workspaceID, resourceType, ref)
+	if err != nil {
+		return nil, err
+	}
+	cached := *result
+	c.resolved.Store(key, &cached)
+	return result, nil
+}
+
+func (c *Client) resolve(ctx context.Context, workspaceID, resourceType, ref string) (*Resource, error) {
+	id := ""
+	switch {
+	case isGID(ref):
+		id = ref
+	case strings.HasPrefix(ref, "external:"):
+		id = ref
+	case resourceType == "user" && strings.Contains(ref, "@"):
+		id = ref
+	case strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://"):
+		link, err := ParseWebURL(ref)
+		if err != nil {
+			return nil, err
+		}
+		if id = link.id(resourceType); id == "" {
+			return nil, errors.Errorf("Link %q does not refer to a %s", ref, resourceType)
+		}
+	}
+	if id != "" {
+		result := &Resource{}
+		_, err := c.get(ctx, fmt.Sprintf("/%ss/%s", resourceType, url.PathEscape(id)), nil, result, &Options{Fields: resolveFields})
+		if e, ok := IsAsanaError(err); !ok || e.StatusCode != 404 {
+			return result, err
+		}
+		// A number which is not a GID may still be a name
+		if !isGID(ref) || workspaceID == "" {
+			return nil, &UnknownReferenceError{Reference: ref, ResourceType: resourceType}
+		}
+	}
+
+	if workspaceID == "" {
+		return nil, errors.Errorf("A workspace is required to find %s %q by name", resourceType, ref)
+	}
+	workspace := &Workspace{ID: workspaceID}
+	candidates, err := workspace.TypeaheadContext(ctx, c, resourceType, ref, resolveCount)
+	if err != nil {
+		return nil, err
+	}
+	return matchName(ref, resourceType, candidates)
+}
+
+// ResolveWorkspace finds the workspace described by ref, which may be a
+// GID or the name of a workspace the authorized user can access. Names are
+// matched as for Resolve, and lookups are cached in the same way.
+func (c *Client) ResolveWorkspace(ref string) (*Workspace, error) {
+	return c.ResolveWorkspaceContext(context.Background(), ref)
+}
+
+// ResolveWorkspaceContext is like ResolveWorkspace but uses ctx for the API requests
+func (c *Client) ResolveWorkspaceContext(ctx context.Context, ref string) (*Workspace, error) {
+	ref = strings.TrimSpace(ref)
+	if isGID(ref) {
+		return &Workspace{ID: ref}, nil
+	}
+
+	key := "workspace\x00" + ref
+	if cached, ok := c.resolved.Load(key); ok {
+		return resourceWorkspace(cached.(*Resource)), nil
+	}
+
+	c.trace("Resolving workspace %q", ref)
+	workspaces, err := c.AllWorkspacesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*Resource
+	for _, w := range workspaces {
+		candidates = append(candidates, &Resource{ID: w.ID, ResourceType: "workspace", Name: w.Name})
+	}
+	result, err := matchName(ref, "workspace", candidates)
+	if err != nil {
+		return nil, err
+	}
+	c.resolved.Store(key, result)
+	return resourceWorkspace(result), nil
+}
+
+func resourceWorkspace(r *Resource) *Workspace {
+	return &Workspace{ID: r.ID, Name: r.Name}
+}
+
+// matchName picks the candidate with the given name, ignoring case, or
+// failing that the only candidate whose name contains it
+func matchName(name, resourceType string, candidates []*Resource) (*Resource, error) {
+	var exact, partial []*Resource
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.Name, name) {
+			exact = append(exact, candidate)
+		} else if strings.Contains(strings.ToLower(candidate.Name), strings.ToLower(name)) {
+			partial = append(partial, candidate)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	switch len(matches) {
+	case 0:
+		return nil, &UnknownReferenceError{Reference: name, ResourceType: resourceType}
+	case 1:
+		if matches[0].ResourceType == "" {
+			matches[0].ResourceType = resourceType
+		}
+		return matches[0], nil
+	default:
+		return nil, &AmbiguousReferenceError{Reference: name, ResourceType: resourceType, Matches: matches}
+	}
+}
+
+// isGID reports whether s looks like a GID rather than a name
+func isGID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package asana_test
+
+import (
+	"fmt"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestTypeahead(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	for _, name := range []string{"Website redesign", "Mobile app", "Web analytics"} {
+		if _, err := client.CreateProject(&asana.CreateProjectRequest{
+			ProjectBase: asana.ProjectBase{Name: name},
+			Workspace:   workspace.ID,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := workspace.Typeahead(client, asana.TypeaheadProject, "web", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Name != "Website redesign" || results[0].Project() == nil {
+		t.Errorf("Unexpected results %+v", results)
+	}
+	results, err = workspace.Typeahead(client, asana.TypeaheadProject, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, but saw %d", len(results))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Engineering")
+	server.AddWorkspace("Engineering archive")
+	alice := server.AddUser(workspace.ID, "Alice Smith", "alice@example.com")
+	server.AddUser(workspace.ID, "Alice Jones", "ajones@example.com")
+
+	project, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "Website"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := client.CreateTask(&asana.CreateTaskRequest{
+		TaskBase: asana.TaskBase{
+			Name:     "Fix login",
+			External: &asana.ExternalData{ID: "bug-42"},
+		},
+		Projects: []string{project.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := client.ResolveWorkspace("engineering")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.ID != workspace.ID {
+		t.Errorf("Expected workspace %s, but saw %s", workspace.ID, resolved.ID)
+	}
+	if _, err := client.ResolveWorkspace("Marketing"); err == nil {
+		t.Error("Expected an error for an unknown workspace")
+	}
+
+	for ref, expected := range map[string]string{
+		project.ID: project.ID,
+		"website":  project.ID,
+		"Webs":     project.ID,
+		"https://app.asana.com/0/" + project.ID + "/list":                    project.ID,
+		"https://app.asana.com/1/" + workspace.ID + "/project/" + project.ID: project.ID,
+	} {
+		resource, err := client.Resolve(workspace.ID, "project", ref)
+		if err != nil {
+			t.Errorf("%s: %v", ref, err)
+			continue
+		}
+		if resource.Project() == nil || resource.ID != expected {
+			t.Errorf("%s: expected project %s, but saw %+v", ref, expected, resource)
+		}
+	}
+
+	for _, ref := range []string{
+		"external:bug-42",
+		"Fix login",
+		fmt.Sprintf("https://app.asana.com/0/%s/%s/f", project.ID, task.ID),
+	} {
+		resource, err := client.Resolve(workspace.ID, "task", ref)
+		if err != nil {
+			t.Errorf("%s: %v", ref, err)
+			continue
+		}
+		if resource.Task() == nil || resource.ID != task.ID || resource.Name != "Fix login" {
+			t.Errorf("%s: expected task %s, but saw %+v", ref, task.ID, resource)
+		}
+	}
+
+	user, err := client.Resolve(workspace.ID, "user", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.User() == nil || user.ID != alice.ID {
+		t.Errorf("Expected user %s, but saw %+v", alice.ID, user)
+	}
+
+	_, err = client.Resolve(workspace.ID, "user", "alice")
+	if ambiguous, ok := err.(*asana.AmbiguousReferenceError); !ok || len(ambiguous.Matches) != 2 {
+		t.Errorf("Expected an ambiguous reference, but saw %v", err)
+	}
+	_, err = client.Resolve(workspace.ID, "task", "Deploy")
+	if _, ok := err.(*asana.UnknownReferenceError); !ok {
+		t.Errorf("Expected an unknown reference, but saw %v", err)
+	}
+	_, err = client.Resolve(workspace.ID, "task", "external:bug-43")
+	if _, ok := err.(*asana.UnknownReferenceError); !ok {
+		t.Errorf("Expected an unknown reference, but saw %v", err)
+	}
+	if _, err := client.Resolve("", "task", "Fix login"); err == nil {
+		t.Error("Expected an error resolving a name without a workspace")
+	}
+	if _, err := client.Resolve(workspace.ID, "task", "https://app.asana.com/0/"+project.ID); err == nil {
+		t.Error("Expected an error for a project link")
+	}
+
+	// Numbers which are not GIDs are looked up as names
+	year, err := client.CreateProject(&asana.CreateProjectRequest{
+		ProjectBase: asana.ProjectBase{Name: "2024"},
+		Workspace:   workspace.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := client.Resolve(workspace.ID, "project", "2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resource.ID != year.ID || resource.Name != "2024" {
+		t.Errorf("Expected project %s, but saw %+v", year.ID, resource)
+	}
+	_, err = client.Resolve(workspace.ID, "project", "999999")
+	if _, ok := err.(*asana.UnknownReferenceError); !ok {
+		t.Errorf("Expected an unknown reference, but saw %v", err)
+	}
+
+	// Lookups are cached
+	requests := len(server.Requests())
+	if _, err := client.Resolve(workspace.ID, "project", "website"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ResolveWorkspace("engineering"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(server.Requests()); n != requests {
+		t.Errorf("Expected cached results, but saw %d requests", n-requests)
+	}
+}
@@ -2,6 +2,7 @@ package asana
 
 import (
 	"encoding/json"
+	"net/url"
 	"time"
 )
 
@@ -35,6 +36,13 @@ func (d *Date) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
+// EncodeValues implements the query.Encoder interface, so that dates can be
+// used as query parameters
+func (d *Date) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, time.Time(*d).Format(dateLayout))
+	return nil
+}
+
 // Validator types have a Validate method which is called before posting the
 // data to the API
 type Validator interface {
@@ -115,3 +123,121 @@ type Options struct {
 	// Request options
 	Debug *bool `json:"-" url:"-"`
 }
+
+// Resource is a compact reference to an object of any type, used where the
+// API may return more than one kind of object
+type Resource struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource, such as task or project
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The subtype of this resource, if any
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// Read-only. The name of the object.
+	Name string `json:"name,omitempty"`
+}
+
+// Task returns the referenced Task, or nil if this is not a task
+func (r *Resource) Task() *Task {
+	if r == nil || r.ResourceType != "task" {
+		return nil
+	}
+	return &Task{
+		ID: r.ID,
+		TaskBase: TaskBase{
+			Name:            r.Name,
+			ResourceSubtype: r.ResourceSubtype,
+		},
+	}
+}
+
+// Project returns the referenced Project, or nil if this is not a project
+func (r *Resource) Project() *Project {
+	if r == nil || r.ResourceType != "project" {
+		return nil
+	}
+	return &Project{
+		ID: r.ID,
+		ProjectBase: ProjectBase{
+			Name: r.Name,
+		},
+	}
+}
+
+// Story returns the referenced Story, or nil if this is not a story
+func (r *Resource) Story() *Story {
+	if r == nil || r.ResourceType != "story" {
+		return nil
+	}
+	return &Story{
+		ID:              r.ID,
+		ResourceSubtype: r.ResourceSubtype,
+	}
+}
+
+// Section returns the referenced Section, or nil if this is not a section
+func (r *Resource) Section() *Section {
+	if r == nil || r.ResourceType != "section" {
+		return nil
+	}
+	return &Section{
+		ID: r.ID,
+		SectionBase: SectionBase{
+			Name: r.Name,
+		},
+	}
+}
+
+// Goal returns the referenced Goal, or nil if this is not a goal
+func (r *Resource) Goal() *Goal {
+	if r == nil || r.ResourceType != "goal" {
+		return nil
+	}
+	return &Goal{
+		ID: r.ID,
+		GoalBase: GoalBase{
+			Name: r.Name,
+		},
+	}
+}
+
+// Portfolio returns the referenced Portfolio, or nil if this is not a
+// portfolio
+func (r *Resource) Portfolio() *Portfolio {
+	if r == nil || r.ResourceType != "portfolio" {
+		return nil
+	}
+	return &Portfolio{
+		ID: r.ID,
+		PortfolioBase: PortfolioBase{
+			Name: r.Name,
+		},
+	}
+}
+
+// User returns the referenced User, or nil if this is not a user
+func (r *Resource) User() *User {
+	if r == nil || r.ResourceType != "user" {
+		return nil
+	}
+	return &User{
+		ID:   r.ID,
+		Name: r.Name,
+	}
+}
+
+// Tag returns the referenced Tag, or nil if this is not a tag
+func (r *Resource) Tag() *Tag {
+	if r == nil || r.ResourceType != "tag" {
+		return nil
+	}
+	return &Tag{
+		ID: r.ID,
+		TagBase: TagBase{
+			Name: r.Name,
+		},
+	}
+}
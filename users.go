@@ -1,6 +1,9 @@
 package asana
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // User represents an account in Asana that can be given access to various
 // workspaces, projects, and tasks.
@@ -32,54 +35,63 @@ type User struct {
 
 // CurrentUser gets the currently authorized user
 func (c *Client) CurrentUser() (*User, error) {
+	return c.CurrentUserContext(context.Background())
+}
+
+// CurrentUserContext is like CurrentUser but uses ctx for the API request
+func (c *Client) CurrentUserContext(ctx context.Context) (*User, error) {
 
 	result := &User{}
 
-	_, err := c.get("/users/me", nil, result)
+	_, err := c.get(ctx, "/users/me", nil, result)
 
 	return result, err
 }
 
 // Fetch loads the full details for this User
 func (u *User) Fetch(client *Client, options ...*Options) error {
+	return u.FetchContext(context.Background(), client, options...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (u *User) FetchContext(ctx context.Context, client *Client, options ...*Options) error {
 	client.trace("Loading details for user %q", u.ID)
 
-	_, err := client.get(fmt.Sprintf("/users/%s", u.ID), nil, u, options...)
+	_, err := client.get(ctx, fmt.Sprintf("/users/%s", u.ID), nil, u, options...)
 	return err
 }
 
 // Users returns the compact records for all users in the organization visible to the authorized user
 func (w *Workspace) Users(client *Client, options ...*Options) ([]*User, *NextPage, error) {
+	return w.UsersContext(context.Background(), client, options...)
+}
+
+// UsersContext is like Users but uses ctx for the API request
+func (w *Workspace) UsersContext(ctx context.Context, client *Client, options ...*Options) ([]*User, *NextPage, error) {
 	client.trace("Listing users in workspace %s...\n", w.ID)
 	var result []*User
 
 	// Make the request
 	queryOptions := append([]*Options{&Options{Workspace: w.ID}}, options...)
-	nextPage, err := client.get("/users", nil, &result, queryOptions...)
+	nextPage, err := client.get(ctx, "/users", nil, &result, queryOptions...)
 	return result, nextPage, err
 }
 
+// IterateUsers returns an Iterator over the users in this workspace visible to the authorized user
+func (w *Workspace) IterateUsers(ctx context.Context, client *Client, opts ...*Options) *Iterator[*User] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*User, *NextPage, error) {
+		return w.UsersContext(ctx, client, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllUsers repeatedly pages through all available users in a workspace
 func (w *Workspace) AllUsers(client *Client, options ...*Options) ([]*User, error) {
-	var allUsers []*User
-	nextPage := &NextPage{}
-
-	var users []*User
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		users, nextPage, err = w.Users(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
-
-		allUsers = append(allUsers, users...)
-	}
-	return allUsers, nil
+	return w.AllUsersContext(context.Background(), client, options...)
+}
+
+// AllUsersContext is like AllUsers but uses ctx for the API requests
+func (w *Workspace) AllUsersContext(ctx context.Context, client *Client, options ...*Options) ([]*User, error) {
+	it := w.IterateUsers(ctx, client, options...)
+	it.PageSize = 50
+	return it.All()
 }
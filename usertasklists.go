@@ -0,0 +1,94 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+)
+
+// UserTaskList represents the tasks assigned to a particular user, shown in
+// Asana as My Tasks. Each user has one task list in each workspace, which
+// is divided into sections chosen by the user.
+type UserTaskList struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The name of the user task list.
+	Name string `json:"name,omitempty"`
+
+	// Read-only. The owner of the user task list, i.e. the person whose My
+	// Tasks is represented by this resource.
+	Owner *User `json:"owner,omitempty"`
+
+	// Read-only. The workspace in which the user task list is located.
+	Workspace *Workspace `json:"workspace,omitempty"`
+}
+
+// UserTaskList returns the task list of a user in a workspace. The user
+// may be a GID, email address or "me".
+func (c *Client) UserTaskList(userID, workspaceID string, opts ...*Options) (*UserTaskList, error) {
+	return c.UserTaskListContext(context.Background(), userID, workspaceID, opts...)
+}
+
+// UserTaskListContext is like UserTaskList but uses ctx for the API request
+func (c *Client) UserTaskListContext(ctx context.Context, userID, workspaceID string, opts ...*Options) (*UserTaskList, error) {
+	c.trace("Loading task list for user %q in workspace %q", userID, workspaceID)
+
+	result := &UserTaskList{}
+	_, err := c.get(ctx, fmt.Sprintf("/users/%s/user_task_list", userID), &Options{Workspace: workspaceID}, result, opts...)
+	return result, err
+}
+
+// TaskList returns the task list of this user in a workspace
+func (u *User) TaskList(client *Client, workspaceID string, opts ...*Options) (*UserTaskList, error) {
+	return u.TaskListContext(context.Background(), client, workspaceID, opts...)
+}
+
+// TaskListContext is like TaskList but uses ctx for the API request
+func (u *User) TaskListContext(ctx context.Context, client *Client, workspaceID string, opts ...*Options) (*UserTaskList, error) {
+	return client.UserTaskListContext(ctx, u.ID, workspaceID, opts...)
+}
+
+// Fetch loads the full details for this UserTaskList
+func (l *UserTaskList) Fetch(client *Client, opts ...*Options) error {
+	return l.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (l *UserTaskList) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading user task list details for %q", l.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/user_task_lists/%s", l.ID), nil, l, opts...)
+	return err
+}
+
+type userTaskListQuery struct {
+	CompletedSince string `url:"completed_since,omitempty"`
+}
+
+// Tasks returns the tasks in this user task list. If completedSince is
+// given, only tasks which are incomplete or were completed since then are
+// returned. It may be "now" to return only incomplete tasks, or a date or
+// time string.
+func (l *UserTaskList) Tasks(client *Client, completedSince string, opts ...*Options) ([]*Task, *NextPage, error) {
+	return l.TasksContext(context.Background(), client, completedSince, opts...)
+}
+
+// TasksContext is like Tasks but uses ctx for the API request
+func (l *UserTaskList) TasksContext(ctx context.Context, client *Client, completedSince string, opts ...*Options) ([]*Task, *NextPage, error) {
+	client.trace("Listing tasks in user task list %q", l.ID)
+
+	var result []*Task
+
+	// Make the request
+	query := &userTaskListQuery{CompletedSince: completedSince}
+	nextPage, err := client.get(ctx, fmt.Sprintf("/user_task_lists/%s/tasks", l.ID), query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateTasks returns an Iterator over the tasks in this user task list,
+// filtered as for Tasks
+func (l *UserTaskList) IterateTasks(ctx context.Context, client *Client, completedSince string, opts ...*Options) *Iterator[*Task] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Task, *NextPage, error) {
+		return l.TasksContext(ctx, client, completedSince, append([]*Options{page}, opts...)...)
+	})
+}
@@ -0,0 +1,106 @@
+package asana_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	asana "bitbucket.org/mikehouston/asana-go"
+	"bitbucket.org/mikehouston/asana-go/asanatest"
+)
+
+func TestUserTaskList(t *testing.T) {
+	client, server := asanatest.NewClient(t)
+	workspace := server.AddWorkspace("Workspace")
+	alice := server.AddUser(workspace.ID, "Alice", "alice@example.com")
+	other := server.AddWorkspace("Other")
+
+	list, err := alice.TaskList(client, workspace.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Owner == nil || list.Owner.ID != alice.ID || list.Workspace.ID != workspace.ID {
+		t.Errorf("Unexpected task list %+v", list)
+	}
+	same, err := client.UserTaskList("alice@example.com", workspace.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.ID != list.ID {
+		t.Errorf("Expected the same task list, but saw %s and %s", same.ID, list.ID)
+	}
+	if _, err := client.UserTaskList(alice.ID, other.ID); err == nil {
+		t.Error("Expected an error for a workspace the user is not a member of")
+	}
+	fetched := &asana.UserTaskList{ID: list.ID}
+	if err := fetched.Fetch(client); err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Name != "My Tasks" {
+		t.Errorf("Unexpected name %q", fetched.Name)
+	}
+
+	later := server.AddUserTaskListSection(alice.ID, workspace.ID, "Later")
+	var tasks []*asana.Task
+	for _, name := range []string{"Review", "Deploy", "Write report", "Unassigned"} {
+		request := &asana.CreateTaskRequest{
+			TaskBase:  asana.TaskBase{Name: name},
+			Workspace: workspace.ID,
+		}
+		if name != "Unassigned" {
+			request.Assignee = alice.ID
+		}
+		task, err := client.CreateTask(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks = append(tasks, task)
+	}
+	if tasks[0].AssigneeSection == nil || tasks[0].AssigneeSection.Name != "Recently assigned" {
+		t.Errorf("Unexpected assignee section %+v", tasks[0].AssigneeSection)
+	}
+	if tasks[3].AssigneeSection != nil {
+		t.Errorf("Expected no assignee section, but saw %+v", tasks[3].AssigneeSection)
+	}
+
+	// Tasks are listed in My Tasks section order
+	if err := tasks[0].Update(client, &asana.UpdateTaskRequest{AssigneeSection: later.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if tasks[0].AssigneeSection == nil || tasks[0].AssigneeSection.ID != later.ID {
+		t.Errorf("Unexpected assignee section %+v", tasks[0].AssigneeSection)
+	}
+	if err := tasks[3].Update(client, &asana.UpdateTaskRequest{AssigneeSection: later.ID}); err == nil {
+		t.Error("Expected an error moving an unassigned task")
+	}
+	completed := true
+	if err := tasks[1].Update(client, &asana.UpdateTaskRequest{TaskBase: asana.TaskBase{Completed: &completed}}); err != nil {
+		t.Fatal(err)
+	}
+
+	it := list.IterateTasks(context.Background(), client, "")
+	it.PageSize = 1
+	all, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"Deploy", "Write report", "Review"}; !reflect.DeepEqual(names(all), expected) {
+		t.Errorf("Expected %v, but saw %v", expected, names(all))
+	}
+
+	incomplete, _, err := list.Tasks(client, "now")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"Write report", "Review"}; !reflect.DeepEqual(names(incomplete), expected) {
+		t.Errorf("Expected %v, but saw %v", expected, names(incomplete))
+	}
+
+	queried, _, err := client.QueryTasks(&asana.TaskQuery{UserTaskList: list.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queried) != 3 {
+		t.Errorf("Expected 3 tasks, but saw %v", names(queried))
+	}
+}
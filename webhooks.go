@@ -0,0 +1,345 @@
+package asana
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebhookFilter restricts the events delivered to a webhook. An event is
+// delivered if it matches any of the webhook's filters.
+type WebhookFilter struct {
+	// The resource type of the changed resource, such as task or story
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// The resource subtype of the changed resource, such as milestone
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// The action taken on the resource
+	Action EventAction `json:"action,omitempty"`
+
+	// For changed events, the fields which must have changed
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Webhook allows an application to be notified of changes in Asana. Changes
+// to the resource, and to resources contained by it, are delivered as events
+// by POST requests to the target URL.
+//
+// When a webhook is created Asana makes a handshake request to the target,
+// which must complete before the create request returns. See WebhookHandler
+// for an implementation of the target endpoint.
+type Webhook struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. If true, the webhook will send events - if false it is
+	// considered inactive and will not generate events.
+	Active bool `json:"active,omitempty"`
+
+	// Read-only. The resource the webhook is subscribed to
+	Resource *Resource `json:"resource,omitempty"`
+
+	// Read-only. The URL to receive the HTTP POST.
+	Target string `json:"target,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The timestamp when the webhook last received an error when
+	// sending an event to the target.
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+
+	// Read-only. The contents of the last error response sent to the
+	// webhook when attempting to deliver events to the target.
+	LastFailureContent string `json:"last_failure_content,omitempty"`
+
+	// Read-only. The timestamp when the webhook last successfully sent an
+	// event to the target.
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+
+	// Whitelist of filters to apply to events from this webhook.
+	Filters []*WebhookFilter `json:"filters,omitempty"`
+}
+
+// CreateWebhookRequest represents a request to create a new webhook
+type CreateWebhookRequest struct {
+	// Required: The ID of the resource to subscribe to
+	Resource string `json:"resource"`
+
+	// Required: The URL to receive the HTTP POST
+	Target string `json:"target"`
+
+	// Filters to apply to events from this webhook
+	Filters []*WebhookFilter `json:"filters,omitempty"`
+}
+
+// UpdateWebhookRequest represents a request to change the filters of a webhook
+type UpdateWebhookRequest struct {
+	Filters []*WebhookFilter `json:"filters"`
+}
+
+// WebhookQuery specifies which webhooks to return from Webhooks
+type WebhookQuery struct {
+	// Required: The workspace to query for webhooks in
+	Workspace string `url:"workspace"`
+
+	// Only return webhooks for the given resource
+	Resource string `url:"resource,omitempty"`
+}
+
+// CreateWebhook establishes a webhook on a resource
+func (c *Client) CreateWebhook(request *CreateWebhookRequest) (*Webhook, error) {
+	return c.CreateWebhookContext(context.Background(), request)
+}
+
+// CreateWebhookContext is like CreateWebhook but uses ctx for the API request
+func (c *Client) CreateWebhookContext(ctx context.Context, request *CreateWebhookRequest) (*Webhook, error) {
+	c.info("Creating webhook for %s targeting %s", request.Resource, request.Target)
+
+	result := &Webhook{}
+
+	err := c.post(ctx, "/webhooks", request, result)
+	return result, err
+}
+
+// Webhooks returns the compact representation of all webhooks your app has
+// registered for the authenticated user in the given workspace
+func (c *Client) Webhooks(query *WebhookQuery, opts ...*Options) ([]*Webhook, *NextPage, error) {
+	return c.WebhooksContext(context.Background(), query, opts...)
+}
+
+// WebhooksContext is like Webhooks but uses ctx for the API request
+func (c *Client) WebhooksContext(ctx context.Context, query *WebhookQuery, opts ...*Options) ([]*Webhook, *NextPage, error) {
+	c.trace("Listing webhooks in workspace %s", query.Workspace)
+
+	var result []*Webhook
+
+	// Make the request
+	nextPage, err := c.get(ctx, "/webhooks", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// IterateWebhooks returns an Iterator over the webhooks matching query
+func (c *Client) IterateWebhooks(ctx context.Context, query *WebhookQuery, opts ...*Options) *Iterator[*Webhook] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Webhook, *NextPage, error) {
+		return c.WebhooksContext(ctx, query, append([]*Options{page}, opts...)...)
+	})
+}
+
+// Fetch loads the full details for this Webhook
+func (w *Webhook) Fetch(client *Client, opts ...*Options) error {
+	return w.FetchContext(context.Background(), client, opts...)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (w *Webhook) FetchContext(ctx context.Context, client *Client, opts ...*Options) error {
+	client.trace("Loading webhook details for %s", w.ID)
+
+	_, err := client.get(ctx, fmt.Sprintf("/webhooks/%s", w.ID), nil, w, opts...)
+	return err
+}
+
+// Update replaces the filters of this Webhook
+func (w *Webhook) Update(client *Client, request *UpdateWebhookRequest) error {
+	return w.UpdateContext(context.Background(), client, request)
+}
+
+// UpdateContext is like Update but uses ctx for the API request
+func (w *Webhook) UpdateContext(ctx context.Context, client *Client, request *UpdateWebhookRequest) error {
+	client.trace("Updating webhook %s", w.ID)
+
+	err := client.put(ctx, fmt.Sprintf("/webhooks/%s", w.ID), request, w)
+	return err
+}
+
+// Delete permanently removes this Webhook. Requests which were in flight
+// when the webhook was deleted may still be delivered.
+func (w *Webhook) Delete(client *Client) error {
+	return w.DeleteContext(context.Background(), client)
+}
+
+// DeleteContext is like Delete but uses ctx for the API request
+func (w *Webhook) DeleteContext(ctx context.Context, client *Client) error {
+	client.info("Deleting webhook %s", w.ID)
+
+	return client.delete(ctx, fmt.Sprintf("/webhooks/%s", w.ID))
+}
+
+// The largest request body accepted by WebhookHandler
+const maxWebhookBody = 10 << 20
+
+// WebhookHandler is an http.Handler which receives the requests sent to a
+// webhook target. It completes the X-Hook-Secret handshake made when the
+// webhook is created, verifies the X-Hook-Signature of each delivery, and
+// dispatches the events to the registered callbacks.
+//
+// A WebhookHandler serves a single webhook, as each webhook has its own
+// secret. Callbacks are called synchronously, in the order they were
+// registered, before the delivery is acknowledged; if any returns an error
+// the request fails and Asana will retry the delivery later.
+type WebhookHandler struct {
+	// OnHandshake is called with the secret sent during the handshake, and
+	// should store it so that it can be provided to NewWebhookHandler when
+	// the application restarts. The handshake fails if an error is returned.
+	OnHandshake func(secret string) error
+
+	mu        sync.RWMutex
+	secret    []byte
+	callbacks []func(*Event) error
+}
+
+// NewWebhookHandler creates a WebhookHandler. The secret from a previous
+// handshake should be provided if known; if it is empty the handler accepts
+// the next handshake request and uses the secret it provides.
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		secret: []byte(secret),
+	}
+}
+
+// OnEvent registers a callback for every event
+func (h *WebhookHandler) OnEvent(f func(*Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.callbacks = append(h.callbacks, f)
+}
+
+// OnTask registers a callback for events which change a task
+func (h *WebhookHandler) OnTask(f func(*Event, *Task) error) {
+	h.OnEvent(func(e *Event) error {
+		if task := e.Task(); task != nil {
+			return f(e, task)
+		}
+		return nil
+	})
+}
+
+// OnProject registers a callback for events which change a project
+func (h *WebhookHandler) OnProject(f func(*Event, *Project) error) {
+	h.OnEvent(func(e *Event) error {
+		if project := e.Project(); project != nil {
+			return f(e, project)
+		}
+		return nil
+	})
+}
+
+// OnStory registers a callback for events which change a story
+func (h *WebhookHandler) OnStory(f func(*Event, *Story) error) {
+	h.OnEvent(func(e *Event) error {
+		if story := e.Story(); story != nil {
+			return f(e, story)
+		}
+		return nil
+	})
+}
+
+// OnSection registers a callback for events which change a section
+func (h *WebhookHandler) OnSection(f func(*Event, *Section) error) {
+	h.OnEvent(func(e *Event) error {
+		if section := e.Section(); section != nil {
+			return f(e, section)
+		}
+		return nil
+	})
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if secret := r.Header.Get("X-Hook-Secret"); secret != "" {
+		h.handshake(w, secret)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "Unable to read request", http.StatusBadRequest)
+		return
+	}
+	if !h.verify(body, r.Header.Get("X-Hook-Signature")) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload struct {
+		Events []*Event `json:"events"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "Unable to parse events", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(payload.Events); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handshake accepts the secret for a new webhook, unless one is already known
+func (h *WebhookHandler) handshake(w http.ResponseWriter, secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.secret) > 0 {
+		http.Error(w, "Webhook secret already established", http.StatusForbidden)
+		return
+	}
+	if h.OnHandshake != nil {
+		if err := h.OnHandshake(secret); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.secret = []byte(secret)
+	w.Header().Set("X-Hook-Secret", secret)
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the signature is a valid HMAC of the body
+func (h *WebhookHandler) verify(body []byte, signature string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.secret) == 0 {
+		return false
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
+
+func (h *WebhookHandler) dispatch(events []*Event) error {
+	h.mu.RLock()
+	callbacks := h.callbacks
+	h.mu.RUnlock()
+
+	for _, event := range events {
+		for _, callback := range callbacks {
+			if err := callback(event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
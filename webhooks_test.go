@@ -0,0 +1,108 @@
+package asana
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testEvents = `{
+	"events": [
+		{
+			"user": {"gid": "1", "resource_type": "user"},
+			"created_at": "2022-01-01T12:00:00.000Z",
+			"action": "changed",
+			"resource": {"gid": "2", "resource_type": "task", "resource_subtype": "default_task"},
+			"change": {"field": "name", "action": "changed", "new_value": "Renamed"}
+		},
+		{
+			"action": "added",
+			"resource": {"gid": "3", "resource_type": "story", "resource_subtype": "comment_added"},
+			"parent": {"gid": "2", "resource_type": "task"}
+		}
+	]
+}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h http.Handler, body string, header map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	for key, value := range header {
+		r.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestWebhookHandshake(t *testing.T) {
+	var stored string
+	h := NewWebhookHandler("")
+	h.OnHandshake = func(secret string) error {
+		stored = secret
+		return nil
+	}
+
+	w := deliver(h, "", map[string]string{"X-Hook-Secret": "secret"})
+	if w.Code != http.StatusOK || w.Header().Get("X-Hook-Secret") != "secret" {
+		t.Errorf("Expected the secret to be echoed, but saw %d %v", w.Code, w.Header())
+	}
+	if stored != "secret" {
+		t.Errorf("Expected the secret to be stored, but saw %q", stored)
+	}
+
+	// A second handshake must not replace the secret
+	w = deliver(h, "", map[string]string{"X-Hook-Secret": "other"})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected a second handshake to be rejected, but saw %d", w.Code)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	h := NewWebhookHandler("secret")
+	called := false
+	h.OnEvent(func(*Event) error {
+		called = true
+		return nil
+	})
+
+	w := deliver(h, testEvents, map[string]string{"X-Hook-Signature": sign("wrong", testEvents)})
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("Expected an invalid signature to be rejected, but saw %d", w.Code)
+	}
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	h := NewWebhookHandler("secret")
+
+	var tasks []*Task
+	var stories []*Story
+	h.OnTask(func(e *Event, task *Task) error {
+		tasks = append(tasks, task)
+		return nil
+	})
+	h.OnStory(func(e *Event, story *Story) error {
+		stories = append(stories, story)
+		return nil
+	})
+
+	w := deliver(h, testEvents, map[string]string{"X-Hook-Signature": sign("secret", testEvents)})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected events to be accepted, but saw %d %s", w.Code, w.Body)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != "2" || tasks[0].ResourceSubtype != "default_task" {
+		t.Errorf("Expected one task event, but saw %+v", tasks)
+	}
+	if len(stories) != 1 || stories[0].ID != "3" || stories[0].Target == nil || stories[0].Target.ID != "2" {
+		t.Errorf("Expected one story event on task 2, but saw %+v", stories)
+	}
+}
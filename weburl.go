@@ -0,0 +1,213 @@
+package asana
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WebHost is the host of the Asana web app
+const WebHost = "app.asana.com"
+
+var webViews = map[string]View{
+	"list":     ViewList,
+	"board":    ViewBoard,
+	"timeline": ViewTimeline,
+	"calendar": ViewCalendar,
+}
+
+// WebURL is a link to a task, project or section in the Asana web app.
+// The objects referred to by a link have only their IDs filled in.
+//
+// Two forms of link are understood. Older links give the project and then
+// the task, with 0 in place of the project for a task viewed on its own:
+//
+//	https://app.asana.com/0/<project>/<task>
+//	https://app.asana.com/0/<project>/<task>/f
+//	https://app.asana.com/0/<project>/board
+//
+// Newer links start with the workspace and name the type of each object:
+//
+//	https://app.asana.com/1/<workspace>/project/<project>/task/<task>
+//	https://app.asana.com/1/<workspace>/task/<task>?focus=true
+//	https://app.asana.com/1/<workspace>/project/<project>/timeline/<view>
+type WebURL struct {
+	// The workspace, which is only given in newer links
+	Workspace *Workspace
+
+	Project *Project
+	Section *Section
+	Task    *Task
+
+	// The view of the project, if the link is to a project rather than a
+	// task
+	View View
+
+	// True if the task is shown full screen in focus mode
+	Focus bool
+}
+
+// ParseWebURL parses a link to the Asana web app. An error is returned if
+// the link is not to a task, project or section, such as a link to the
+// inbox.
+func ParseWebURL(link string) (*WebURL, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid link %q", link)
+	}
+	if u.Host != WebHost {
+		return nil, errors.Errorf("%q is not a link to the Asana web app", link)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	result := &WebURL{Focus: u.Query().Get("focus") == "true"}
+	if len(segments) > 1 {
+		switch segments[0] {
+		case "0":
+			result.parseLegacy(segments[1:])
+		case "1":
+			result.parse(segments[1:])
+		}
+	}
+	if result.Project == nil && result.Section == nil && result.Task == nil {
+		return nil, errors.Errorf("%q is not a link to a task, project or section", link)
+	}
+	if result.Section != nil && result.Project != nil {
+		result.Section.Project = result.Project
+	}
+	return result, nil
+}
+
+// parseLegacy parses the path of an older link after the leading /0
+func (w *WebURL) parseLegacy(segments []string) {
+	if !isGID(segments[0]) {
+		return
+	}
+	if segments[0] != "0" {
+		w.Project = &Project{ID: segments[0]}
+	}
+	for _, segment := range segments[1:] {
+		switch {
+		case segment == "f":
+			w.Focus = true
+		case isGID(segment):
+			w.Task = &Task{ID: segment}
+		case webViews[segment] != "":
+			w.View = webViews[segment]
+		}
+	}
+}
+
+// parse parses the path of a newer link after the leading /1
+func (w *WebURL) parse(segments []string) {
+	if !isGID(segments[0]) {
+		return
+	}
+	w.Workspace = &Workspace{ID: segments[0]}
+	for i := 1; i < len(segments); i++ {
+		id := ""
+		if i+1 < len(segments) && isGID(segments[i+1]) {
+			id = segments[i+1]
+		}
+		switch segments[i] {
+		case "project":
+			w.Project = &Project{ID: id}
+		case "section":
+			w.Section = &Section{ID: id}
+		case "task":
+			w.Task = &Task{ID: id}
+		default:
+			if view, ok := webViews[segments[i]]; ok {
+				w.View = view
+			}
+		}
+		if id != "" {
+			i++
+		}
+	}
+	if w.Project != nil && w.Project.ID == "" {
+		w.Project = nil
+	}
+	if w.Section != nil && w.Section.ID == "" {
+		w.Section = nil
+	}
+	if w.Task != nil && w.Task.ID == "" {
+		w.Task = nil
+	}
+}
+
+// id returns the ID of the object of the given type in the link, if any
+func (w *WebURL) id(resourceType string) string {
+	switch {
+	case resourceType == "task" && w.Task != nil:
+		return w.Task.ID
+	case resourceType == "project" && w.Project != nil:
+		return w.Project.ID
+	case resourceType == "section" && w.Section != nil:
+		return w.Section.ID
+	case resourceType == "workspace" && w.Workspace != nil:
+		return w.Workspace.ID
+	}
+	return ""
+}
+
+// String builds the link. The newer form is used if the workspace is
+// known, and the older form otherwise. Older links cannot refer to a
+// section on its own, so a link to a section and no task is only given in
+// the newer form; without a workspace the link is to the section's
+// project.
+func (w *WebURL) String() string {
+	var path []string
+	query := ""
+
+	project := w.Project
+	if project == nil && w.Section != nil {
+		project = w.Section.Project
+	}
+
+	if w.Workspace != nil {
+		path = []string{"1", w.Workspace.ID}
+		if project != nil {
+			path = append(path, "project", project.ID)
+		}
+		switch {
+		case w.Task != nil:
+			path = append(path, "task", w.Task.ID)
+		case w.Section != nil:
+			path = append(path, "section", w.Section.ID)
+		case w.View != "":
+			path = append(path, string(w.View))
+		}
+		if w.Focus && w.Task != nil {
+			query = "?focus=true"
+		}
+	} else {
+		projectID := "0"
+		if project != nil {
+			projectID = project.ID
+		}
+		path = []string{"0", projectID}
+		switch {
+		case w.Task != nil:
+			path = append(path, w.Task.ID)
+			if w.Focus {
+				path = append(path, "f")
+			}
+		case project == nil:
+			return ""
+		case w.View != "":
+			path = append(path, string(w.View))
+		default:
+			path = append(path, string(ViewList))
+		}
+	}
+
+	return "https://" + WebHost + "/" + strings.Join(path, "/") + query
+}
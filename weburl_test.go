@@ -0,0 +1,115 @@
+package asana
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWebURL(t *testing.T) {
+	for link, expected := range map[string]string{
+		"https://app.asana.com/0/12/34":                             "project 12, task 34",
+		"https://app.asana.com/0/12/34/f":                           "project 12, task 34, focus",
+		"https://app.asana.com/0/0/34/f":                            "task 34, focus",
+		"https://app.asana.com/0/12/board":                          "project 12, view board",
+		"https://app.asana.com/0/12/list":                           "project 12, view list",
+		"https://app.asana.com/1/9/project/12/task/34":              "workspace 9, project 12, task 34",
+		"https://app.asana.com/1/9/task/34?focus=true":              "workspace 9, task 34, focus",
+		"https://app.asana.com/1/9/project/12/timeline/56":          "workspace 9, project 12, view timeline",
+		"https://app.asana.com/1/9/project/12/section/78":           "workspace 9, project 12, section 78",
+		"https://app.asana.com/1/9/project/12/list/56?focus=false":  "workspace 9, project 12, view list",
+		" https://app.asana.com/1/9/project/12/board/56/task/34 ":   "workspace 9, project 12, task 34, view board",
+		"https://app.asana.com/1/9/project/12/calendar":             "workspace 9, project 12, view calendar",
+		"https://app.asana.com/0/inbox/9":                           "error",
+		"https://app.asana.com/1/9/inbox/12":                        "error",
+		"https://app.asana.com/0/0/list":                            "error",
+		"https://example.com/0/12/34":                               "error",
+		"not a link":                                                "error",
+		"https://app.asana.com/0/portfolio/12/list":                 "error",
+		"https://app.asana.com/1/9/portfolio/12/list/56":            "error",
+		"https://app.asana.com/1/workspace/project/12/task/34":      "error",
+		"https://app.asana.com/0/12/34/56":                          "project 12, task 56",
+		"https://app.asana.com/1/9/project/12/overview/56?focus=no": "workspace 9, project 12",
+	} {
+		parsed, err := ParseWebURL(link)
+		if err != nil {
+			if expected != "error" {
+				t.Errorf("%s: %v", link, err)
+			}
+			continue
+		}
+		if actual := describeWebURL(parsed); actual != expected {
+			t.Errorf("%s: expected %q, but saw %q", link, expected, actual)
+		}
+	}
+}
+
+func describeWebURL(w *WebURL) string {
+	var parts []string
+	if w.Workspace != nil {
+		parts = append(parts, "workspace "+w.Workspace.ID)
+	}
+	if w.Project != nil {
+		parts = append(parts, "project "+w.Project.ID)
+	}
+	if w.Section != nil {
+		if w.Section.Project != w.Project {
+			parts = append(parts, "section project mismatch")
+		}
+		parts = append(parts, "section "+w.Section.ID)
+	}
+	if w.Task != nil {
+		parts = append(parts, "task "+w.Task.ID)
+	}
+	if w.View != "" {
+		parts = append(parts, "view "+string(w.View))
+	}
+	if w.Focus {
+		parts = append(parts, "focus")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestWebURL_String(t *testing.T) {
+	workspace := &Workspace{ID: "9"}
+	project := &Project{ID: "12"}
+	task := &Task{ID: "34"}
+	section := &Section{ID: "78", Project: project}
+
+	for expected, link := range map[string]*WebURL{
+		"https://app.asana.com/0/12/34":                           {Project: project, Task: task},
+		"https://app.asana.com/0/12/34/f":                         {Project: project, Task: task, Focus: true},
+		"https://app.asana.com/0/0/34":                            {Task: task},
+		"https://app.asana.com/0/12/list":                         {Project: project},
+		"https://app.asana.com/0/12/board":                        {Project: project, View: ViewBoard},
+		"https://app.asana.com/0/12/timeline":                     {Section: section, View: ViewTimeline},
+		"https://app.asana.com/1/9/project/12/task/34":            {Workspace: workspace, Project: project, Task: task},
+		"https://app.asana.com/1/9/task/34?focus=true":            {Workspace: workspace, Task: task, Focus: true},
+		"https://app.asana.com/1/9/project/12/section/78":         {Workspace: workspace, Section: section},
+		"https://app.asana.com/1/9/project/12/timeline":           {Workspace: workspace, Project: project, View: ViewTimeline},
+		"https://app.asana.com/1/9/project/12":                    {Workspace: workspace, Project: project},
+		"https://app.asana.com/1/9/project/12/task/34?focus=true": {Workspace: workspace, Project: project, Task: task, Focus: true},
+	} {
+		if actual := link.String(); actual != expected {
+			t.Errorf("Expected %q, but saw %q", expected, actual)
+		}
+	}
+
+	if actual := (&WebURL{}).String(); actual != "" {
+		t.Errorf("Expected no link, but saw %q", actual)
+	}
+
+	// Links round trip through ParseWebURL
+	for _, link := range []*WebURL{
+		{Project: project, Task: task, Focus: true},
+		{Workspace: workspace, Section: section},
+		{Workspace: workspace, Project: project, View: ViewBoard},
+	} {
+		parsed, err := ParseWebURL(link.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.String() != link.String() {
+			t.Errorf("Expected %q, but saw %q", link.String(), parsed.String())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -35,44 +36,47 @@ type Workspace struct {
 
 // Fetch loads the full details for this Workspace
 func (w *Workspace) Fetch(client *Client) error {
+	return w.FetchContext(context.Background(), client)
+}
+
+// FetchContext is like Fetch but uses ctx for the API request
+func (w *Workspace) FetchContext(ctx context.Context, client *Client) error {
 	client.trace("Loading details for workspace %s\n", w.ID)
 
-	_, err := client.get(fmt.Sprintf("/workspaces/%s", w.ID), nil, w)
+	_, err := client.get(ctx, fmt.Sprintf("/workspaces/%s", w.ID), nil, w)
 	return err
 }
 
 // Workspaces returns workspaces and organizations accessible to the currently
 // authorized account
 func (c *Client) Workspaces(options ...*Options) ([]*Workspace, *NextPage, error) {
+	return c.WorkspacesContext(context.Background(), options...)
+}
+
+// WorkspacesContext is like Workspaces but uses ctx for the API request
+func (c *Client) WorkspacesContext(ctx context.Context, options ...*Options) ([]*Workspace, *NextPage, error) {
 	c.trace("Listing workspaces...\n")
 	var result []*Workspace
 
 	// Make the request
-	nextPage, err := c.get("/workspaces", nil, &result, options...)
+	nextPage, err := c.get(ctx, "/workspaces", nil, &result, options...)
 	return result, nextPage, err
 }
 
+// IterateWorkspaces returns an Iterator over the workspaces and organizations accessible to the currently
+// authorized account
+func (c *Client) IterateWorkspaces(ctx context.Context, opts ...*Options) *Iterator[*Workspace] {
+	return NewIterator(ctx, func(ctx context.Context, page *Options) ([]*Workspace, *NextPage, error) {
+		return c.WorkspacesContext(ctx, append([]*Options{page}, opts...)...)
+	})
+}
+
 // AllWorkspaces repeatedly pages through all available workspaces for a client
 func (c *Client) AllWorkspaces(options ...*Options) ([]*Workspace, error) {
-	allWorkspaces := []*Workspace{}
-	nextPage := &NextPage{}
-
-	var workspaces []*Workspace
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		workspaces, nextPage, err = c.Workspaces(allOptions...)
-		if err != nil {
-			return nil, err
-		}
+	return c.AllWorkspacesContext(context.Background(), options...)
+}
 
-		allWorkspaces = append(allWorkspaces, workspaces...)
-	}
-	return allWorkspaces, nil
+// AllWorkspacesContext is like AllWorkspaces but uses ctx for the API requests
+func (c *Client) AllWorkspacesContext(ctx context.Context, options ...*Options) ([]*Workspace, error) {
+	return c.IterateWorkspaces(ctx, options...).All()
 }